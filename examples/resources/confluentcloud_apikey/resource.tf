@@ -0,0 +1,7 @@
+resource "confluentcloud_apikey" "orders-app-kafka-api-key" {
+  environment_id = confluentcloud_environment.test-env.id
+  owner_id       = confluentcloud_service_account.orders-app-sa.id
+  resource_id    = confluentcloud_kafka_cluster.basic-cluster.id
+  resource_type  = "kafka"
+  description    = "Kafka API Key for orders app"
+}
@@ -0,0 +1,12 @@
+resource "confluentcloud_ksql_statement" "pageviews_female" {
+  http_endpoint = confluentcloud_ksqldb_cluster.orders.endpoint
+  credentials {
+    key    = "<ksqlDB API Key for confluentcloud_ksqldb_cluster.orders>"
+    secret = "<ksqlDB API Secret for confluentcloud_ksqldb_cluster.orders>"
+  }
+
+  statement = "CREATE STREAM pageviews_female AS SELECT * FROM pageviews WHERE gender = 'FEMALE';"
+  properties = {
+    "ksql.streams.auto.offset.reset" = "earliest"
+  }
+}
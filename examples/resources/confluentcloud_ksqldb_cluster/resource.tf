@@ -0,0 +1,16 @@
+resource "confluentcloud_ksqldb_cluster" "payments" {
+  name                = "payments"
+  environment_id      = confluentcloud_environment.prod.id
+  kafka_id            = confluentcloud_kafka_cluster.basic.id
+  credential_identity = confluentcloud_service_account.ksqldb.id
+  csu                 = 4
+}
+
+resource "confluentcloud_ksqldb_cluster" "orders" {
+  name             = "orders"
+  environment_id   = confluentcloud_environment.prod.id
+  kafka_id         = confluentcloud_kafka_cluster.basic.id
+  kafka_api_key    = confluentcloud_apikey.ksqldb.key
+  kafka_api_secret = confluentcloud_apikey.ksqldb.secret
+  csu              = 1
+}
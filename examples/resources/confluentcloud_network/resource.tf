@@ -0,0 +1,16 @@
+resource "confluentcloud_environment" "development" {
+  display_name = "Development"
+}
+
+resource "confluentcloud_network" "aws-peering" {
+  display_name     = "AWS Peering Network"
+  cloud            = "AWS"
+  region           = "us-east-2"
+  cidr             = "10.10.0.0/16"
+  zones            = ["use2-az1", "use2-az2", "use2-az3"]
+  connection_types = ["PEERING"]
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+}
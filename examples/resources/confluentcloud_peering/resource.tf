@@ -0,0 +1,33 @@
+resource "confluentcloud_environment" "development" {
+  display_name = "Development"
+}
+
+resource "confluentcloud_network" "aws-peering" {
+  display_name     = "AWS Peering Network"
+  cloud            = "AWS"
+  region           = "us-east-2"
+  cidr             = "10.10.0.0/16"
+  connection_types = ["PEERING"]
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+}
+
+resource "confluentcloud_peering" "aws" {
+  display_name = "AWS Peering"
+  aws {
+    account         = "012345678901"
+    vpc             = "vpc-abcdef0123456789a"
+    routes          = ["172.31.0.0/16"]
+    customer_region = "us-east-2"
+  }
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+
+  network {
+    id = confluentcloud_network.aws-peering.id
+  }
+}
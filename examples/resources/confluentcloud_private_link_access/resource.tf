@@ -0,0 +1,30 @@
+resource "confluentcloud_environment" "development" {
+  display_name = "Development"
+}
+
+resource "confluentcloud_network" "aws-private-link" {
+  display_name     = "AWS Private Link Network"
+  cloud            = "AWS"
+  region           = "us-east-1"
+  connection_types = ["PRIVATELINK"]
+  zones            = ["use1-az1", "use1-az2", "use1-az6"]
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+}
+
+resource "confluentcloud_private_link_access" "aws" {
+  display_name = "AWS Private Link Access"
+  aws {
+    account = "012345678901"
+  }
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+
+  network {
+    id = confluentcloud_network.aws-private-link.id
+  }
+}
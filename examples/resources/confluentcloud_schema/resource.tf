@@ -0,0 +1,17 @@
+resource "confluentcloud_schema" "orders" {
+  http_endpoint = confluentcloud_schema_registry.prod.endpoint
+  credentials {
+    key    = "<Schema Registry API Key for confluentcloud_schema_registry.prod>"
+    secret = "<Schema Registry API Secret for confluentcloud_schema_registry.prod>"
+  }
+
+  subject_name = "orders-value"
+  format       = "AVRO"
+  schema       = file("./schemas/avro/order.avsc")
+
+  schema_reference {
+    name         = "io.confluent.examples.Customer"
+    subject_name = "customer-value"
+    version      = 1
+  }
+}
@@ -0,0 +1,10 @@
+resource "confluentcloud_environment" "prod" {
+  display_name = "Production"
+}
+
+resource "confluentcloud_schema_registry" "prod" {
+  environment_id   = confluentcloud_environment.prod.id
+  service_provider = "aws"
+  location         = "us"
+  package          = "essentials"
+}
@@ -0,0 +1,20 @@
+resource "confluentcloud_subject_config" "global" {
+  http_endpoint = confluentcloud_schema_registry.prod.endpoint
+  credentials {
+    key    = "<Schema Registry API Key for confluentcloud_schema_registry.prod>"
+    secret = "<Schema Registry API Secret for confluentcloud_schema_registry.prod>"
+  }
+
+  compatibility_level = "BACKWARD"
+}
+
+resource "confluentcloud_subject_config" "orders" {
+  http_endpoint = confluentcloud_schema_registry.prod.endpoint
+  credentials {
+    key    = "<Schema Registry API Key for confluentcloud_schema_registry.prod>"
+    secret = "<Schema Registry API Secret for confluentcloud_schema_registry.prod>"
+  }
+
+  subject_name        = "orders-value"
+  compatibility_level = "BACKWARD_TRANSITIVE"
+}
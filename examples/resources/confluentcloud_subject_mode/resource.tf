@@ -0,0 +1,21 @@
+resource "confluentcloud_subject_mode" "migration" {
+  http_endpoint = confluentcloud_schema_registry.prod.endpoint
+  credentials {
+    key    = "<Schema Registry API Key for confluentcloud_schema_registry.prod>"
+    secret = "<Schema Registry API Secret for confluentcloud_schema_registry.prod>"
+  }
+
+  mode  = "IMPORT"
+  force = true
+}
+
+resource "confluentcloud_subject_mode" "orders" {
+  http_endpoint = confluentcloud_schema_registry.prod.endpoint
+  credentials {
+    key    = "<Schema Registry API Key for confluentcloud_schema_registry.prod>"
+    secret = "<Schema Registry API Secret for confluentcloud_schema_registry.prod>"
+  }
+
+  subject_name = "orders-value"
+  mode         = "READONLY"
+}
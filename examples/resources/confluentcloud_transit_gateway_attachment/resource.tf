@@ -0,0 +1,32 @@
+resource "confluentcloud_environment" "development" {
+  display_name = "Development"
+}
+
+resource "confluentcloud_network" "aws-transit-gateway" {
+  display_name     = "AWS Transit Gateway Network"
+  cloud            = "AWS"
+  region           = "us-east-2"
+  cidr             = "10.10.0.0/16"
+  connection_types = ["TRANSITGATEWAY"]
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+}
+
+resource "confluentcloud_transit_gateway_attachment" "aws" {
+  display_name = "AWS Transit Gateway Attachment"
+  aws {
+    ram_share_arn      = "arn:aws:ram:us-east-2:012345678901:resource-share/abcdef01-2345-6789-abcd-ef0123456789"
+    transit_gateway_id = "tgw-abcdef0123456789a"
+    routes             = ["192.168.0.0/16", "172.16.0.0/12", "100.64.0.0/10", "10.0.0.0/8"]
+  }
+
+  environment {
+    id = confluentcloud_environment.development.id
+  }
+
+  network {
+    id = confluentcloud_network.aws-transit-gateway.id
+  }
+}
@@ -3,6 +3,7 @@ module github.com/confluentinc/terraform-provider-ccloud
 go 1.15
 
 require (
+	github.com/ProtonMail/go-crypto v0.0.0-20210428141323-04723f9f07d7
 	github.com/antihax/optional v1.0.0
 	github.com/confluentinc/ccloud-sdk-go-v2/cmk v0.3.0
 	github.com/confluentinc/ccloud-sdk-go-v2/iam v0.5.0
@@ -13,6 +14,8 @@ require (
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/go-retryablehttp v0.7.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.10.1
+	github.com/jhump/protoreflect v1.6.0
+	github.com/linkedin/goavro/v2 v2.11.1
 	github.com/stretchr/testify v1.7.0
 	github.com/testcontainers/testcontainers-go v0.11.0
 	github.com/walkerus/go-wiremock v1.2.0
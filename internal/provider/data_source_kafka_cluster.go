@@ -72,6 +72,7 @@ func kafkaDataSource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			paramNetwork: kafkaClusterNetworkDataSourceSchema(),
 		},
 	}
 }
@@ -156,6 +157,17 @@ func kafkaDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 	if err := d.Set(paramRbacCrn, rbacCrn); err != nil {
 		return createDiagnosticsWithDetails(err)
 	}
+	networkId := ""
+	if isKafkaClusterInNetworkPossible(cluster) {
+		networkId, resp, err = executeKafkaNetworkRead(ctx, c, environmentId, clusterId)
+		if err != nil {
+			log.Printf("[ERROR] Kafka cluster network get failed for id %s, %v, %s", clusterId, resp, err)
+			return createDiagnosticsWithDetails(err)
+		}
+	}
+	if err := setNetworkId(networkId, d); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
 	if err := setEnvironmentId(environmentId, d); err != nil {
 		return createDiagnosticsWithDetails(err)
 	}
@@ -202,3 +214,20 @@ func dedicatedClusterDataSourceSchema() *schema.Schema {
 		},
 	}
 }
+
+func kafkaClusterNetworkDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramId: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the network, for example, `n-abc123`.",
+				},
+			},
+		},
+		Computed:    true,
+		Description: "The network the Kafka cluster is attached to, empty for clusters with public networking.",
+	}
+}
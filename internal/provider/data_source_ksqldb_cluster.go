@@ -0,0 +1,136 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ksqlDbClusterDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ksqlDbClusterDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"environment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"kafka_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"topic_prefix": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"storage": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"csu": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ksqlDbClusterDataSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	environmentId := d.Get("environment_id").(string)
+	clusterId := d.Get("id").(string)
+
+	var cluster ksqlDbCluster
+	var err error
+	if clusterId != "" {
+		log.Printf("[INFO] ksqlDB cluster read for %s", clusterId)
+		cluster, _, err = executeKsqlDbClusterRead(ctx, c, environmentId, clusterId)
+	} else {
+		name := d.Get("name").(string)
+		log.Printf("[INFO] ksqlDB cluster read for %s", name)
+		cluster, err = executeKsqlDbClusterLookupByName(ctx, c, environmentId, name)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(cluster.Id)
+	d.Set("name", cluster.Name)
+	d.Set("kafka_id", cluster.KafkaClusterId)
+	d.Set("endpoint", cluster.Endpoint)
+	d.Set("topic_prefix", cluster.OutputToicPrefix)
+	d.Set("storage", cluster.Storage)
+	d.Set("csu", cluster.TotalNumCsu)
+	d.Set("status", cluster.Status)
+
+	return nil
+}
+
+func executeKsqlDbClusterLookupByName(ctx context.Context, c *Client, environmentId, name string) (ksqlDbCluster, error) {
+	type responseBody struct {
+		Clusters []ksqlDbCluster
+		Error    string
+	}
+
+	var resp responseBody
+	_, err := c.legacyClient.Get(ctx, "/ksqls", url.Values{"account_id": {environmentId}}, &resp)
+	if err != nil {
+		return ksqlDbCluster{}, err
+	}
+	if resp.Error != "" {
+		return ksqlDbCluster{}, fmt.Errorf("unexpected API response: %s", resp.Error)
+	}
+
+	var matches []ksqlDbCluster
+	for _, cluster := range resp.Clusters {
+		if cluster.Name == name {
+			matches = append(matches, cluster)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ksqlDbCluster{}, fmt.Errorf("no ksqlDB cluster named %q in %s", name, environmentId)
+	case 1:
+		return matches[0], nil
+	default:
+		return ksqlDbCluster{}, fmt.Errorf("found %d ksqlDB clusters named %q in %s, use id to pick one", len(matches), name, environmentId)
+	}
+}
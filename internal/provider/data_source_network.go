@@ -0,0 +1,146 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The Networks API returns up to 100 networks per page
+const networkListPageSize = "100"
+
+func networkDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: networkDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			paramId: {
+				Type:        schema.TypeString,
+				Description: "The ID of the Network (e.g., `n-abc123`).",
+				Computed:    true,
+				Optional:    true,
+				// A user should provide a value for either "id" or "display_name" attribute
+				ExactlyOneOf: []string{paramId, paramDisplayName},
+			},
+			paramDisplayName: {
+				Type:         schema.TypeString,
+				Description:  "A human-readable name for the Network.",
+				Computed:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{paramId, paramDisplayName},
+			},
+			paramEnvironment: environmentDataSourceSchema(),
+			paramCloud: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			paramRegion: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			paramConnectionTypes: {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			paramCidr: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			paramZones: {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			paramDnsDomain: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			paramZonalSubdomains: {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func networkDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	// ExactlyOneOf specified in the schema ensures one of paramId or paramDisplayName is specified.
+	networkId := d.Get(paramId).(string)
+	displayName := d.Get(paramDisplayName).(string)
+
+	var network networkingV1Network
+	if networkId != "" {
+		log.Printf("[INFO] Network read using \"%s\"=%s", paramId, networkId)
+		var resp *http.Response
+		network, resp, err = executeNetworkRead(ctx, c, environmentId, networkId)
+		if err != nil {
+			log.Printf("[ERROR] Network get failed for id %s, %v, %s", networkId, resp, err)
+		}
+	} else {
+		log.Printf("[INFO] Network read using \"%s\"=%s", paramDisplayName, displayName)
+		network, err = executeNetworkLookupByDisplayName(ctx, c, environmentId, displayName)
+	}
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	d.SetId(network.Id)
+	if err := setNetworkAttributes(d, network); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := setEnvironmentId(environmentId, d); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	return nil
+}
+
+func executeNetworkLookupByDisplayName(ctx context.Context, c *Client, environmentId, displayName string) (networkingV1Network, error) {
+	var networkList networkingV1NetworkList
+	resp, err := c.legacyClient.Get(ctx, networksPath, url.Values{"environment": {environmentId}, "page_size": {networkListPageSize}}, &networkList)
+	if err != nil {
+		log.Printf("[ERROR] Networks get failed %v, %s", resp, err)
+		return networkingV1Network{}, err
+	}
+
+	var matches []networkingV1Network
+	for _, network := range networkList.Data {
+		if network.Spec.DisplayName == displayName {
+			matches = append(matches, network)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return networkingV1Network{}, fmt.Errorf("the Network with display_name=%s was not found in %s", displayName, environmentId)
+	case 1:
+		return matches[0], nil
+	default:
+		return networkingV1Network{}, fmt.Errorf("there are multiple Networks with display_name=%s in %s", displayName, environmentId)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func schemaDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: schemaDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			paramHttpEndpoint: schemaRegistryHttpEndpointDataSourceSchema(),
+			paramCredentials:  credentialsSchema(),
+			paramSubjectName: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			paramVersion: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The version of the subject to read. Omit it to read the latest version.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			paramSchema: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			paramSchemaId: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			paramFormat: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			paramSchemaReference: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						paramName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						paramSubjectName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						paramVersion: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			paramCompatibilityLevel: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// schemaRegistryHttpEndpointDataSourceSchema is shared by the data sources that talk to a Schema Registry cluster.
+func schemaRegistryHttpEndpointDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "The REST endpoint of the Schema Registry cluster, for example, `https://psrc-00000.us-central1.gcp.confluent.cloud`.",
+		ValidateFunc: validation.IsURLWithHTTPS,
+	}
+}
+
+func schemaDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	subjectName := d.Get(paramSubjectName).(string)
+	version := latestSchemaVersion
+	if v := d.Get(paramVersion).(int); v != 0 {
+		version = strconv.Itoa(v)
+	}
+	log.Printf("[INFO] Schema read for version %s of subject %s", version, subjectName)
+
+	schemaVersion, resp, err := c.readSubjectVersion(ctx, subjectName, version)
+	if err != nil {
+		log.Printf("[ERROR] Schema get failed for version %s of subject %s, %v, %s", version, subjectName, resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+	compatibilityLevel, resp, err := c.readEffectiveCompatibilityLevel(ctx, subjectName)
+	if err != nil {
+		log.Printf("[ERROR] Compatibility level get failed for subject %s, %v, %s", subjectName, resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+
+	if err := d.Set(paramVersion, schemaVersion.Version); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramSchema, schemaVersion.Schema); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramSchemaId, schemaVersion.Id); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramFormat, schemaVersion.format()); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramSchemaReference, flattenSchemaReferences(schemaVersion.References)); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramCompatibilityLevel, compatibilityLevel); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	d.SetId(fmt.Sprintf("%s/%d", subjectName, schemaVersion.Version))
+	return nil
+}
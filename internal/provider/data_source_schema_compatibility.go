@@ -0,0 +1,120 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramCheckAllVersions = "check_all_versions"
+	paramIsCompatible     = "is_compatible"
+	paramMessages         = "messages"
+)
+
+func schemaCompatibilityDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: schemaCompatibilityDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			paramHttpEndpoint: schemaRegistryHttpEndpointDataSourceSchema(),
+			paramCredentials:  credentialsSchema(),
+			paramSubjectName: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			paramFormat: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(acceptedSchemaFormats, false),
+			},
+			paramSchema: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			paramSchemaReference: schemaReferenceSchema(),
+			paramCheckAllVersions: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check the schema against all versions of the subject instead of the latest one only.",
+			},
+			paramIsCompatible: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			paramMessages: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func schemaCompatibilityDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subjectName := d.Get(paramSubjectName).(string)
+	format := d.Get(paramFormat).(string)
+	schemaDefinition := d.Get(paramSchema).(string)
+
+	var result compatibilityCheckResult
+	references := extractSchemaReferences(d)
+	if err := validateSchemaSyntax(format, schemaDefinition, references); err != nil {
+		// A malformed schema is reported as incompatible rather than as an error, so that preconditions can gate on it
+		log.Printf("[WARN] Schema for subject %s is malformed: %s", subjectName, err)
+		result = compatibilityCheckResult{
+			IsCompatible: false,
+			Messages:     []string{err.Error()},
+		}
+	} else {
+		c, err := schemaRegistryRestClientFromResourceData(d, meta)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		log.Printf("[INFO] Schema compatibility check for subject %s", subjectName)
+
+		request := newRegisterSchemaRequest(format, schemaDefinition, references)
+		var resp *http.Response
+		result, resp, err = c.testCompatibility(ctx, subjectName, request, d.Get(paramCheckAllVersions).(bool))
+		if err != nil {
+			if !HasStatusNotFound(resp) {
+				log.Printf("[ERROR] Schema compatibility check failed for subject %s, %v, %s", subjectName, resp, err)
+				return createDiagnosticsWithDetails(err)
+			}
+			// Any schema can be registered under a subject that doesn't exist yet
+			result = compatibilityCheckResult{
+				IsCompatible: true,
+				Messages:     []string{fmt.Sprintf("subject %s has no versions to check against", subjectName)},
+			}
+		}
+	}
+
+	if err := d.Set(paramIsCompatible, result.IsCompatible); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramMessages, result.Messages); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	d.SetId(subjectName)
+	return nil
+}
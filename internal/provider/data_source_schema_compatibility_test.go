@@ -0,0 +1,38 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCompatibilityDataSourceReadMalformedSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, schemaCompatibilityDataSource().Schema, map[string]interface{}{
+		paramHttpEndpoint: "https://psrc-00000.us-central1.gcp.confluent.cloud",
+		paramSubjectName:  "orders-value",
+		paramFormat:       schemaFormatProtobuf,
+		paramSchema:       "message Order { int32 = ; }",
+	})
+
+	// The malformed schema is never sent to Schema Registry
+	require.Empty(t, schemaCompatibilityDataSourceRead(context.Background(), d, &Client{}))
+	require.Equal(t, "orders-value", d.Id())
+	require.False(t, d.Get(paramIsCompatible).(bool))
+	require.Equal(t, []interface{}{"invalid Protobuf schema: schema.proto:1:23: syntax error: unexpected '='"}, d.Get(paramMessages))
+}
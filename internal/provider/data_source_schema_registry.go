@@ -2,11 +2,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"time"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,86 +16,111 @@ func dataSourceSchemaRegistry() *schema.Resource {
 		ReadContext: dataSourceSchemaRegistryRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the Schema Registry, for example, `lsrc-abc123`. Omit it to look up the Schema Registry of the environment.",
+			},
 			"environment_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Environment that the Schema Registry belongs to.",
 			},
 			"name": {
 				Type:     schema.TypeString,
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kafka_cluster_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_schemas": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"service_provider": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"package": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 		},
 	}
 }
 
-func dataSourceSchemaRegistryRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-
-	type schemaRegistryCluster struct {
-		Id                  string
-		Name                string
-		Kafka_cluster_id    string
-		Endpoint            string
-		Created             string
-		Modified            string
-		Status              string
-		Physical_cluster_id string
-		Account_id          string
-		Organization_id     int
-		Max_schemas         int
-		Org_resource_id     string
-	}
-
-	type responseBody struct {
-		Error    string
-		Clusters []schemaRegistryCluster
-	}
-
+func dataSourceSchemaRegistryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
-	environment_id := d.Get("environment_id").(string)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	url := fmt.Sprintf("%s/schema_registries?account_id=%s", "https://api.confluent.cloud", environment_id)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	environmentId := d.Get("environment_id").(string)
+	schemaRegistryId := d.Get("id").(string)
+
+	var cluster schemaRegistryCluster
+	var err error
+	if schemaRegistryId != "" {
+		var resp *http.Response
+		cluster, resp, err = executeSchemaRegistryRead(ctx, c, environmentId, schemaRegistryId)
+		if HasStatusNotFound(resp) {
+			return diag.Errorf("no Schema Registry with ID %s in %s", schemaRegistryId, environmentId)
+		}
+	} else {
+		cluster, err = executeSchemaRegistryLookupByEnvironment(ctx, c, environmentId)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("req: %v", req)
-	log.Printf("client: %v", client)
-
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
-	r, err := client.Do(req)
-	if err != nil {
+	d.SetId(cluster.Id)
+	if err := d.Set("name", cluster.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("region", cluster.ServiceProviderRegion); err != nil {
 		return diag.FromErr(err)
 	}
-	defer r.Body.Close()
+	return diag.FromErr(setSchemaRegistryAttributes(d, cluster))
+}
 
-	if r.StatusCode != 200 {
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
+// executeSchemaRegistryLookupByEnvironment returns the Schema Registry of the environment, an environment has at most one.
+func executeSchemaRegistryLookupByEnvironment(ctx context.Context, c *Client, environmentId string) (schemaRegistryCluster, error) {
+	type responseBody struct {
+		Error    string
+		Clusters []schemaRegistryCluster
 	}
 
 	var resp responseBody
-
-	err = json.NewDecoder(r.Body).Decode(&resp)
+	_, err := c.legacyClient.Get(ctx, "/schema_registries", url.Values{"account_id": {environmentId}}, &resp)
 	if err != nil {
-		return diag.FromErr(err)
+		return schemaRegistryCluster{}, err
 	}
-
 	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+		return schemaRegistryCluster{}, fmt.Errorf("unexpected API response: %s", resp.Error)
 	}
 
-	d.Set("name", resp.Clusters[0].Name)
-	d.SetId(resp.Clusters[0].Id)
-
-	return diags
+	switch len(resp.Clusters) {
+	case 0:
+		return schemaRegistryCluster{}, fmt.Errorf("no Schema Registry in %s", environmentId)
+	case 1:
+		return resp.Clusters[0], nil
+	default:
+		log.Printf("[WARN] Found %d Schema Registries in %s", len(resp.Clusters), environmentId)
+		return schemaRegistryCluster{}, fmt.Errorf("found %d Schema Registries in %s, set id to pick one", len(resp.Clusters), environmentId)
+	}
 }
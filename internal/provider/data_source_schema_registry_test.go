@@ -0,0 +1,43 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteSchemaRegistryLookupByEnvironment(t *testing.T) {
+	clusters := `[]`
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/schema_registries", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"clusters": ` + clusters + `}`))
+	})
+
+	_, err := executeSchemaRegistryLookupByEnvironment(context.Background(), c, "env-abc123")
+	require.EqualError(t, err, "no Schema Registry in env-abc123")
+
+	clusters = `[{"id": "lsrc-abc123", "endpoint": "https://psrc-00000.us-central1.gcp.confluent.cloud", "status": "UP", "max_schemas": 1000, "package": "ESSENTIALS"}]`
+	cluster, err := executeSchemaRegistryLookupByEnvironment(context.Background(), c, "env-abc123")
+	require.NoError(t, err)
+	require.Equal(t, "lsrc-abc123", cluster.Id)
+	require.Equal(t, "https://psrc-00000.us-central1.gcp.confluent.cloud", cluster.Endpoint)
+	require.Equal(t, 1000, cluster.MaxSchemas)
+}
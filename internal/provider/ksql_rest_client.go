@@ -0,0 +1,150 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	ksqlCommandStatusSuccess = "SUCCESS"
+)
+
+// ksqlCommandIdRegex matches command IDs like stream/`PAGEVIEWS`/create
+var ksqlCommandIdRegex = regexp.MustCompile("^(stream|table)/`(.+)`/")
+
+// ksqlSourceNotFoundRegex matches the error ksqlDB returns for DESCRIBE, TERMINATE and DROP of a source that doesn't exist
+var ksqlSourceNotFoundRegex = regexp.MustCompile("(?i)(could not find|does not exist)")
+
+// KsqlRestClient talks to the REST API of a ksqlDB cluster using a ksqlDB API key,
+// the same way KafkaRestClient talks to a Kafka cluster.
+type KsqlRestClient struct {
+	client *LegacyClient
+	// statementClient doesn't retry: a retried CREATE that had succeeded would fail because the source already exists
+	statementClient *LegacyClient
+	httpEndpoint    string
+}
+
+type KsqlRestClientFactory struct {
+	userAgent string
+}
+
+func (f KsqlRestClientFactory) CreateKsqlRestClient(httpEndpoint, apiKey, apiSecret string) *KsqlRestClient {
+	return &KsqlRestClient{
+		client:          NewLegacyClient(httpEndpoint, f.userAgent, apiKey, apiSecret),
+		statementClient: newLegacyClientWithHttpClient(&http.Client{}, httpEndpoint, f.userAgent, apiKey, apiSecret),
+		httpEndpoint:    httpEndpoint,
+	}
+}
+
+type ksqlRequest struct {
+	Ksql              string            `json:"ksql"`
+	StreamsProperties map[string]string `json:"streamsProperties,omitempty"`
+}
+
+type ksqlCommandStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	QueryId string `json:"queryId"`
+}
+
+type ksqlQuery struct {
+	Id string `json:"id"`
+}
+
+type ksqlSourceDescription struct {
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	Topic        string      `json:"topic"`
+	WriteQueries []ksqlQuery `json:"writeQueries"`
+}
+
+type ksqlStatementResult struct {
+	Type              string                `json:"@type"`
+	StatementText     string                `json:"statementText"`
+	CommandId         string                `json:"commandId"`
+	CommandStatus     ksqlCommandStatus     `json:"commandStatus"`
+	SourceDescription ksqlSourceDescription `json:"sourceDescription"`
+}
+
+type ksqlError struct {
+	Type      string `json:"@type"`
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// sourceTypeAndName extracts the type (STREAM or TABLE) and the name of the source a command created.
+func (r ksqlStatementResult) sourceTypeAndName() (string, string, bool) {
+	matches := ksqlCommandIdRegex.FindStringSubmatch(r.CommandId)
+	if matches == nil {
+		return "", "", false
+	}
+	return strings.ToUpper(matches[1]), matches[2], true
+}
+
+// executeStatements runs the statements once and returns one result per statement.
+func (c *KsqlRestClient) executeStatements(ctx context.Context, statements string, streamsProperties map[string]string) ([]ksqlStatementResult, *http.Response, error) {
+	return c.execute(ctx, c.statementClient, statements, streamsProperties)
+}
+
+func (c *KsqlRestClient) execute(ctx context.Context, client *LegacyClient, statements string, streamsProperties map[string]string) ([]ksqlStatementResult, *http.Response, error) {
+	var results []ksqlStatementResult
+	resp, err := client.Post(ctx, "/ksql", nil, ksqlRequest{Ksql: statements, StreamsProperties: streamsProperties}, &results)
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, result := range results {
+		if result.Type == "currentStatus" && result.CommandStatus.Status != ksqlCommandStatusSuccess {
+			return results, resp, fmt.Errorf("ksqlDB statement %q has status %s: %s", result.StatementText, result.CommandStatus.Status, result.CommandStatus.Message)
+		}
+	}
+	return results, resp, nil
+}
+
+// describeSource returns the description of the stream or table.
+func (c *KsqlRestClient) describeSource(ctx context.Context, sourceName string) (ksqlSourceDescription, *http.Response, error) {
+	// DESCRIBE doesn't change anything, so it can be retried
+	results, resp, err := c.execute(ctx, c.client, fmt.Sprintf("DESCRIBE %s;", quoteKsqlIdentifier(sourceName)), nil)
+	if err != nil {
+		return ksqlSourceDescription{}, resp, err
+	}
+	if len(results) == 0 {
+		return ksqlSourceDescription{}, resp, fmt.Errorf("ksqlDB returned no description of %s", sourceName)
+	}
+	return results[0].SourceDescription, resp, nil
+}
+
+func quoteKsqlIdentifier(identifier string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(identifier, "`", "``"))
+}
+
+// isKsqlSourceNotFoundError returns true if ksqlDB rejected the statement because the stream or table doesn't exist.
+func isKsqlSourceNotFoundError(err error) bool {
+	var apiError *LegacyApiError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	var ksqlErr ksqlError
+	if json.Unmarshal([]byte(apiError.Body), &ksqlErr) != nil {
+		return false
+	}
+	return ksqlSourceNotFoundRegex.MatchString(ksqlErr.Message)
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKsqlRestClientExecuteStatements(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ksql", r.URL.Path)
+		var request ksqlRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.Header().Set("Content-Type", "application/json")
+		switch request.Ksql {
+		case "CREATE STREAM PAGEVIEWS_FEMALE AS SELECT * FROM PAGEVIEWS WHERE GENDER = 'FEMALE';":
+			require.Equal(t, "earliest", request.StreamsProperties["ksql.streams.auto.offset.reset"])
+			_, _ = w.Write([]byte(`[{"@type": "currentStatus", "commandId": "stream/` + "`PAGEVIEWS_FEMALE`" + `/create", "commandStatus": {"status": "SUCCESS", "message": "Created query with ID CSAS_PAGEVIEWS_FEMALE_1", "queryId": "CSAS_PAGEVIEWS_FEMALE_1"}}]`))
+		case "DESCRIBE `PAGEVIEWS_MALE`;":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"@type": "statement_error", "error_code": 40001, "message": "Could not find STREAM/TABLE 'PAGEVIEWS_MALE' in the Metastore"}`))
+		default:
+			t.Fatalf("unexpected statement %s", request.Ksql)
+		}
+	}))
+	defer server.Close()
+
+	client := KsqlRestClientFactory{userAgent: "test-user-agent"}.CreateKsqlRestClient(server.URL, "foo", "bar")
+	results, _, err := client.executeStatements(context.Background(), "CREATE STREAM PAGEVIEWS_FEMALE AS SELECT * FROM PAGEVIEWS WHERE GENDER = 'FEMALE';",
+		map[string]string{"ksql.streams.auto.offset.reset": "earliest"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	sourceType, sourceName, ok := results[0].sourceTypeAndName()
+	require.True(t, ok)
+	require.Equal(t, "STREAM", sourceType)
+	require.Equal(t, "PAGEVIEWS_FEMALE", sourceName)
+	require.Equal(t, "CSAS_PAGEVIEWS_FEMALE_1", results[0].CommandStatus.QueryId)
+
+	_, _, err = client.describeSource(context.Background(), "PAGEVIEWS_MALE")
+	require.Error(t, err)
+	require.True(t, isKsqlSourceNotFoundError(err))
+}
+
+func TestKsqlRestClientDoesNotRetryStatements(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := KsqlRestClientFactory{userAgent: "test-user-agent"}.CreateKsqlRestClient(server.URL, "foo", "bar")
+	_, resp, err := client.executeStatements(context.Background(), "CREATE STREAM PAGEVIEWS_FEMALE AS SELECT * FROM PAGEVIEWS WHERE GENDER = 'FEMALE';", nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, 1, requestCount)
+}
+
+func TestKsqlCreateSourceStatementRegex(t *testing.T) {
+	require.True(t, ksqlCreateSourceStatementRegex.MatchString("CREATE STREAM pageviews (viewtime BIGINT) WITH (KAFKA_TOPIC='pageviews', VALUE_FORMAT='JSON');"))
+	require.True(t, ksqlCreateSourceStatementRegex.MatchString("\n  create or replace table users_by_region AS SELECT region, COUNT(*) FROM users GROUP BY region;"))
+	require.True(t, ksqlCreateSourceStatementRegex.MatchString("CREATE SOURCE TABLE users (id STRING PRIMARY KEY) WITH (KAFKA_TOPIC='users', VALUE_FORMAT='JSON');"))
+	require.False(t, ksqlCreateSourceStatementRegex.MatchString("INSERT INTO pageviews (viewtime) VALUES (1);"))
+	require.False(t, ksqlCreateSourceStatementRegex.MatchString("CREATE STREAMING pageviews;"))
+}
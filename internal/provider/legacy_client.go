@@ -0,0 +1,173 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// LegacyClient sends requests to the Confluent Cloud APIs that are not covered by ccloud-sdk-go-v2
+// (API keys, Schema Registry, ksqlDB clusters and networking). It honors the provider's endpoint, user agent and credentials
+// and retries failed requests like the SDK-based clients do, see createLegacyRetryableHttpClient().
+type LegacyClient struct {
+	httpClient *http.Client
+	endpoint   string
+	userAgent  string
+	apiKey     string
+	apiSecret  string
+}
+
+// LegacyApiError is returned for any non-2xx response of the legacy APIs.
+type LegacyApiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *LegacyApiError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+func NewLegacyClient(endpoint, userAgent, apiKey, apiSecret string) *LegacyClient {
+	return newLegacyClientWithHttpClient(createLegacyRetryableHttpClient(), endpoint, userAgent, apiKey, apiSecret)
+}
+
+// createLegacyRetryableHttpClient works like createRetryableHttpClientWithExponentialBackoff() except that
+// POST and PATCH requests are only retried after a 429 or a connection error: a 5xx doesn't tell whether
+// the server has already created the API key or cluster, so retrying could create a duplicate.
+func createLegacyRetryableHttpClient() *http.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.CheckRetry = legacyClientRetryPolicy
+	return retryClient.StandardClient()
+}
+
+func legacyClientRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if err == nil && resp != nil && resp.Request != nil && !isIdempotentMethod(resp.Request.Method) &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return false, nil
+	}
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+func isIdempotentMethod(method string) bool {
+	return method != http.MethodPost && method != http.MethodPatch
+}
+
+// newLegacyClientWithHttpClient lets non-idempotent requests, which must not be retried, use a plain HTTP client.
+func newLegacyClientWithHttpClient(httpClient *http.Client, endpoint, userAgent, apiKey, apiSecret string) *LegacyClient {
+	return &LegacyClient{
+		httpClient: httpClient,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		userAgent:  userAgent,
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+	}
+}
+
+// Get, Post, Put, Patch and Delete marshal requestBody (unless it is nil) to JSON, send the request and decode
+// the JSON response into responseBody (unless it is nil).
+// The returned *http.Response (with an already consumed body) is meant to be used with HasStatusNotFound() and friends.
+func (c *LegacyClient) Get(ctx context.Context, path string, query url.Values, responseBody interface{}) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, path, query, nil, responseBody)
+}
+
+func (c *LegacyClient) Post(ctx context.Context, path string, query url.Values, requestBody, responseBody interface{}) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, path, query, requestBody, responseBody)
+}
+
+func (c *LegacyClient) Put(ctx context.Context, path string, query url.Values, requestBody, responseBody interface{}) (*http.Response, error) {
+	return c.do(ctx, http.MethodPut, path, query, requestBody, responseBody)
+}
+
+func (c *LegacyClient) Patch(ctx context.Context, path string, query url.Values, requestBody, responseBody interface{}) (*http.Response, error) {
+	return c.do(ctx, http.MethodPatch, path, query, requestBody, responseBody)
+}
+
+func (c *LegacyClient) Delete(ctx context.Context, path string, query url.Values, requestBody, responseBody interface{}) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, path, query, requestBody, responseBody)
+}
+
+func (c *LegacyClient) do(ctx context.Context, method, path string, query url.Values, requestBody, responseBody interface{}) (*http.Response, error) {
+	requestUrl := c.endpoint + path
+	if len(query) > 0 {
+		requestUrl = fmt.Sprintf("%s?%s", requestUrl, query.Encode())
+	}
+
+	var body io.Reader
+	if requestBody != nil {
+		jsonRequestBody, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(jsonRequestBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" && c.apiSecret != "" {
+		req.SetBasicAuth(c.apiKey, c.apiSecret)
+	} else {
+		log.Printf("[WARN] Could not find credentials for Confluent Cloud")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	responseBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, &LegacyApiError{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Body:       strings.TrimSpace(string(responseBytes)),
+		}
+	}
+
+	if responseBody != nil && len(responseBytes) > 0 {
+		if err := json.Unmarshal(responseBytes, responseBody); err != nil {
+			return resp, fmt.Errorf("could not decode response of %s %s: %s", method, path, err)
+		}
+	}
+	return resp, nil
+}
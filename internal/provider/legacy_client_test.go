@@ -0,0 +1,118 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyClient(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		// The first request is throttled to verify the client retries
+		if requestCount == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		require.Equal(t, "/api_keys", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		require.Equal(t, "test-user-agent", r.UserAgent())
+		username, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "foo", username)
+		require.Equal(t, "bar", password)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "value", body["key"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"api_key": {"key": "ABCDEFGH"}}`))
+	}))
+	defer server.Close()
+
+	client := NewLegacyClient(server.URL+"/", "test-user-agent", "foo", "bar")
+	var resp response
+	_, err := client.Post(context.Background(), "/api_keys", url.Values{"account_id": {"env-abc123"}}, map[string]string{"key": "value"}, &resp)
+	require.NoError(t, err)
+	require.Equal(t, "ABCDEFGH", resp.ApiKey.Key)
+	require.Equal(t, 2, requestCount)
+}
+
+func TestLegacyClientDoesNotRetryCreateOnServerError(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewLegacyClient(server.URL, "test-user-agent", "foo", "bar")
+	resp, err := client.Post(context.Background(), "/api_keys", nil, map[string]string{"key": "value"}, nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, 1, requestCount)
+}
+
+func TestLegacyClientRetryPolicy(t *testing.T) {
+	tests := []struct {
+		method     string
+		statusCode int
+		retry      bool
+	}{
+		{http.MethodGet, http.StatusOK, false},
+		{http.MethodGet, http.StatusTooManyRequests, true},
+		{http.MethodGet, http.StatusServiceUnavailable, true},
+		{http.MethodPut, http.StatusBadGateway, true},
+		{http.MethodDelete, http.StatusInternalServerError, true},
+		{http.MethodPost, http.StatusTooManyRequests, true},
+		{http.MethodPost, http.StatusServiceUnavailable, false},
+		{http.MethodPost, http.StatusInternalServerError, false},
+		{http.MethodPatch, http.StatusTooManyRequests, true},
+		{http.MethodPatch, http.StatusBadGateway, false},
+	}
+	for _, test := range tests {
+		resp := &http.Response{StatusCode: test.statusCode, Request: &http.Request{Method: test.method}}
+		retry, _ := legacyClientRetryPolicy(context.Background(), resp, nil)
+		require.Equal(t, test.retry, retry, "%s %d", test.method, test.statusCode)
+	}
+
+	// Connection errors are retried regardless of the method
+	retry, _ := legacyClientRetryPolicy(context.Background(), nil, errors.New("connection refused"))
+	require.True(t, retry)
+}
+
+func TestLegacyClientNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewLegacyClient(server.URL, "test-user-agent", "foo", "bar")
+	resp, err := client.Get(context.Background(), "/ksqls/lksqlc-abc123", nil, nil)
+	require.Error(t, err)
+	require.True(t, HasStatusNotFound(resp))
+	require.Contains(t, err.Error(), `{"error": "not found"}`)
+}
@@ -47,16 +47,19 @@ const (
 )
 
 type Client struct {
-	iamClient              *iam.APIClient
-	iamV1Client            *iamv1.APIClient
-	cmkClient              *cmk.APIClient
-	orgClient              *org.APIClient
-	kafkaRestClientFactory *KafkaRestClientFactory
-	mdsClient              *mds.APIClient
-	userAgent              string
-	apiKey                 string
-	apiSecret              string
-	waitUntil              string
+	iamClient                       *iam.APIClient
+	iamV1Client                     *iamv1.APIClient
+	cmkClient                       *cmk.APIClient
+	orgClient                       *org.APIClient
+	kafkaRestClientFactory          *KafkaRestClientFactory
+	schemaRegistryRestClientFactory *SchemaRegistryRestClientFactory
+	ksqlRestClientFactory           *KsqlRestClientFactory
+	legacyClient                    *LegacyClient
+	mdsClient                       *mds.APIClient
+	userAgent                       string
+	apiKey                          string
+	apiSecret                       string
+	waitUntil                       string
 }
 
 // Customize configs for terraform-plugin-docs
@@ -112,22 +115,34 @@ func New(version string) func() *schema.Provider {
 				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"confluentcloud_environment":     environmentDataSource(),
-				"confluentcloud_kafka_cluster":   kafkaDataSource(),
-				"confluentcloud_kafka_topic":     kafkaTopicDataSource(),
-				"confluentcloud_schema_registry": dataSourceSchemaRegistry(),
-				"confluentcloud_service_account": serviceAccountDataSource(),
+				"confluentcloud_environment":          environmentDataSource(),
+				"confluentcloud_kafka_cluster":        kafkaDataSource(),
+				"confluentcloud_kafka_topic":          kafkaTopicDataSource(),
+				"confluentcloud_ksqldb_cluster":       ksqlDbClusterDataSource(),
+				"confluentcloud_network":              networkDataSource(),
+				"confluentcloud_schema_registry":      dataSourceSchemaRegistry(),
+				"confluentcloud_schema":               schemaDataSource(),
+				"confluentcloud_schema_compatibility": schemaCompatibilityDataSource(),
+				"confluentcloud_service_account":      serviceAccountDataSource(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"confluentcloud_apikey":          resourceApiKey(),
-				"confluentcloud_environment":     environmentResource(),
-				"confluentcloud_kafka_acl":       kafkaAclResource(),
-				"confluentcloud_kafka_cluster":   kafkaResource(),
-				"confluentcloud_kafka_topic":     kafkaTopicResource(),
-				"confluentcloud_ksqldb_cluster":  resourceKsqlDbCluster(),
-				"confluentcloud_role_binding":    roleBindingResource(),
-				"confluentcloud_service_account": serviceAccountResource(),
-				"confluentcloud_schema_registry": resourceSchemaRegistry(),
+				"confluentcloud_apikey":                     resourceApiKey(),
+				"confluentcloud_environment":                environmentResource(),
+				"confluentcloud_kafka_acl":                  kafkaAclResource(),
+				"confluentcloud_kafka_cluster":              kafkaResource(),
+				"confluentcloud_kafka_topic":                kafkaTopicResource(),
+				"confluentcloud_ksqldb_cluster":             resourceKsqlDbCluster(),
+				"confluentcloud_ksql_statement":             resourceKsqlStatement(),
+				"confluentcloud_network":                    networkResource(),
+				"confluentcloud_peering":                    peeringResource(),
+				"confluentcloud_private_link_access":        privateLinkAccessResource(),
+				"confluentcloud_role_binding":               roleBindingResource(),
+				"confluentcloud_service_account":            serviceAccountResource(),
+				"confluentcloud_schema_registry":            resourceSchemaRegistry(),
+				"confluentcloud_schema":                     resourceSchema(),
+				"confluentcloud_subject_config":             resourceSubjectConfig(),
+				"confluentcloud_subject_mode":               resourceSubjectMode(),
+				"confluentcloud_transit_gateway_attachment": transitGatewayAttachmentResource(),
 			},
 		}
 
@@ -239,16 +254,19 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 	orgCfg.HTTPClient = createRetryableHttpClientWithExponentialBackoff()
 
 	client := Client{
-		cmkClient:              cmk.NewAPIClient(cmkCfg),
-		iamClient:              iam.NewAPIClient(iamCfg),
-		iamV1Client:            iamv1.NewAPIClient(iamV1Cfg),
-		orgClient:              org.NewAPIClient(orgCfg),
-		kafkaRestClientFactory: &KafkaRestClientFactory{userAgent: userAgent},
-		mdsClient:              mds.NewAPIClient(mdsCfg),
-		userAgent:              userAgent,
-		apiKey:                 apiKey,
-		apiSecret:              apiSecret,
-		waitUntil:              waitUntil,
+		cmkClient:                       cmk.NewAPIClient(cmkCfg),
+		iamClient:                       iam.NewAPIClient(iamCfg),
+		iamV1Client:                     iamv1.NewAPIClient(iamV1Cfg),
+		orgClient:                       org.NewAPIClient(orgCfg),
+		kafkaRestClientFactory:          &KafkaRestClientFactory{userAgent: userAgent},
+		schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: userAgent},
+		ksqlRestClientFactory:           &KsqlRestClientFactory{userAgent: userAgent},
+		legacyClient:                    NewLegacyClient(endpoint, userAgent, apiKey, apiSecret),
+		mdsClient:                       mds.NewAPIClient(mdsCfg),
+		userAgent:                       userAgent,
+		apiKey:                          apiKey,
+		apiSecret:                       apiSecret,
+		waitUntil:                       waitUntil,
 	}
 
 	return &client, nil
@@ -1,47 +1,123 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	paramCreatedAt      = "created_at"
+	paramRotation       = "rotation"
+	paramRotateAfter    = "rotate_after"
+	paramRotateAt       = "rotate_at"
+	paramKeepPrevious   = "keep_previous"
+	paramPreviousKey    = "previous_key"
+	paramPreviousSecret = "previous_secret"
+	paramOwnerKind      = "owner_kind"
+
+	paramPgpKey                  = "pgp_key"
+	paramEncryptedSecret         = "encrypted_secret"
+	paramKeyFingerprint          = "key_fingerprint"
+	paramPreviousEncryptedSecret = "previous_encrypted_secret"
+
+	ownerKindServiceAccount = "service_account"
+	ownerKindUser           = "user"
+	serviceAccountIdPrefix  = "sa-"
+	userIdPrefix            = "u-"
+
+	apiKeyResourceTypeKafka          = "kafka"
+	apiKeyResourceTypeSchemaRegistry = "schema_registry"
+	apiKeyResourceTypeKsql           = "ksql"
+	apiKeyResourceTypeCloud          = "cloud"
+
+	apiKeyImportSecretEnvVar = "API_KEY_SECRET"
+	apiKeyImportPgpKeyEnvVar = "API_KEY_PGP_KEY"
+)
+
+var acceptedApiKeyResourceTypes = []string{apiKeyResourceTypeKafka, apiKeyResourceTypeSchemaRegistry, apiKeyResourceTypeKsql, apiKeyResourceTypeCloud}
+
+// Resource ID prefixes used to infer resource_type when it's omitted
+var apiKeyResourceTypeByResourceIdPrefix = map[string]string{
+	"lkc-":    apiKeyResourceTypeKafka,
+	"lsrc-":   apiKeyResourceTypeSchemaRegistry,
+	"lksqlc-": apiKeyResourceTypeKsql,
+}
+
+var paramRotationRotateAfter = fmt.Sprintf("%s.0.%s", paramRotation, paramRotateAfter)
+var paramRotationKeepPrevious = fmt.Sprintf("%s.0.%s", paramRotation, paramKeepPrevious)
+
 func resourceApiKey() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceApiKeyCreate,
 		ReadContext:   resourceApiKeyRead,
+		UpdateContext: resourceApiKeyUpdate,
 		DeleteContext: resourceApiKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceApiKeyImport,
+		},
+		CustomizeDiff: resourceApiKeyCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"key": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"secret": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API Secret. It's empty when `pgp_key` is set.",
+			},
+			paramPgpKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A base64-encoded PGP public key, or a Keybase username in the form `keybase:<username>`, used to encrypt the API Secret.",
+			},
+			paramEncryptedSecret: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The base64-encoded API Secret encrypted with `pgp_key`.",
+			},
+			paramKeyFingerprint: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fingerprint of the PGP key used to encrypt the API Secret.",
 			},
 			"environment_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the Environment that the API Key belongs to. It's required unless resource_type is cloud.",
 			},
 			"description": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A free-form description of the API Key.",
 			},
 			"owner_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the Service Account (e.g., `sa-abc123`) or the User (e.g., `u-abc123`) that owns the API Key.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^(sa|u)-"), "the owner ID must be of the form 'sa-' or 'u-'"),
+			},
+			paramOwnerKind: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kind of the owner of the API Key, either `service_account` or `user`.",
 			},
 			"owner_email": &schema.Schema{
 				Type:     schema.TypeString,
@@ -49,19 +125,83 @@ func resourceApiKey() *schema.Resource {
 				ForceNew: true,
 			},
 			"resource_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The type of the resource the API Key grants access to. When omitted, it's inferred from `resource_id`.",
+				ValidateFunc: validation.StringInSlice(acceptedApiKeyResourceTypes, false),
 			},
 			"resource_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the resource the API Key grants access to. Omit it to create a Cloud API Key.",
+			},
+			paramCreatedAt: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the current API Key was created, in RFC 3339 format.",
+			},
+			paramRotation: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Time-based rotation of the API Key.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						paramRotateAfter: {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The age after which a new API Key is issued on the next apply, for example, `720h`.",
+							ValidateDiagFunc: validateRotateAfter,
+						},
+						paramKeepPrevious: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							Description:  "The number of rotated API Keys to keep active until the next apply (0 or 1).",
+							ValidateFunc: validation.IntBetween(0, 1),
+						},
+					},
+				},
+			},
+			paramRotateAt: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time from which the next plan rotates the API Key, in RFC 3339 format. It's empty when rotation is disabled.",
+			},
+			paramPreviousKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API Key that was replaced by the latest rotation. It's revoked on the next apply.",
+			},
+			paramPreviousSecret: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API Secret of `previous_key`. It's empty when `pgp_key` is set.",
+			},
+			paramPreviousEncryptedSecret: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The base64-encoded API Secret of `previous_key` encrypted with `pgp_key`.",
 			},
 		},
 	}
 }
 
+func validateRotateAfter(i interface{}, path cty.Path) diag.Diagnostics {
+	rotateAfter, err := time.ParseDuration(i.(string))
+	if err != nil {
+		return diag.Errorf("%s must be a duration, for example, 720h: %s", paramRotateAfter, err)
+	}
+	if rotateAfter <= 0 {
+		return diag.Errorf("%s must be positive", paramRotateAfter)
+	}
+	return nil
+}
+
 type clientLogicalCluster struct {
 	Name string
 }
@@ -99,14 +239,53 @@ type response struct {
 	Error  string
 }
 
+type listApiKeysResponse struct {
+	ApiKeys []apiKey `json:"api_keys"`
+	Error   string
+}
+
 func resourceApiKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+	c := m.(*Client)
+
+	pgpEntity, err := retrieveApiKeyPgpKey(ctx, d.Get(paramPgpKey).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createdApiKey, err := executeApiKeyCreate(ctx, c, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(createdApiKey.Key)
+	log.Printf("[DEBUG] Created API key %s", d.Id())
+
+	ownerKind, err := ownerKindOf(d.Get("owner_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(paramOwnerKind, ownerKind); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(setCreatedApiKeyAttributes(d, createdApiKey, pgpEntity))
+}
+
+// retrieveApiKeyPgpKey resolves pgp_key, or returns nil when it isn't set. It's called before an API key is created
+// so that its secret isn't lost when the PGP key can't be retrieved.
+func retrieveApiKeyPgpKey(ctx context.Context, pgpKey string) (*openpgp.Entity, error) {
+	if pgpKey == "" {
+		return nil, nil
+	}
+	return retrievePgpKey(ctx, pgpKey)
+}
 
+func executeApiKeyCreate(ctx context.Context, c *Client, d *schema.ResourceData) (apiKey, error) {
 	type apiKeyRequest struct {
-		AccountId       string           `json:"accountId"`
+		AccountId       string           `json:"accountId,omitempty"`
 		Description     string           `json:"description"`
-		LogicalClusters []logicalCluster `json:"logicalClusters"`
-		UserId          int              `json:"userId"`
+		LogicalClusters []logicalCluster `json:"logicalClusters,omitempty"`
+		UserId          int              `json:"userId,omitempty"`
 		UserResourceId  string           `json:"userResourceId"`
 	}
 
@@ -114,89 +293,551 @@ func resourceApiKeyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		ApiKey apiKeyRequest `json:"apiKey"`
 	}
 
-	c := m.(*Client)
 	environmentId := d.Get("environment_id").(string)
 	resourceId := d.Get("resource_id").(string)
-	resourceType := d.Get("resource_type").(string)
+	resourceType, err := apiKeyResourceType(d.Get("resource_type").(string), resourceId)
+	if err != nil {
+		return apiKey{}, err
+	}
 	description := d.Get("description").(string)
 	userResourceId := d.Get("owner_id").(string)
-	userId, err := saResourceIdToSaIntegerId(c, userResourceId)
+	userId, err := resolveApiKeyOwnerIntegerId(ctx, c, userResourceId)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiKey{}, err
 	}
 
-	log.Printf("ERIC: userinfo: rsr %s, id-int: %v", userResourceId, userId)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	url := fmt.Sprintf("%s/api_keys?account_id=%s", "https://api.confluent.cloud", environmentId)
+	// Cloud API keys aren't bound to any logical cluster
+	var logicalClusters []logicalCluster
+	if resourceType != apiKeyResourceTypeCloud {
+		logicalClusters = []logicalCluster{{Id: resourceId, Type: resourceType}}
+	}
 
-	post_data := request{
+	createRequest := request{
 		apiKeyRequest{
 			AccountId:       environmentId,
 			Description:     description,
-			LogicalClusters: []logicalCluster{{Id: resourceId, Type: resourceType}},
+			LogicalClusters: logicalClusters,
 			UserId:          userId,
 			UserResourceId:  userResourceId,
 		},
 	}
 
-	json_post_data, err := json.Marshal(post_data)
+	var resp response
+	_, err = c.legacyClient.Post(ctx, "/api_keys", apiKeyQuery(environmentId), createRequest, &resp)
 	if err != nil {
-		return diag.FromErr(err)
+		log.Printf("[ERROR] API key create failed for owner %s, %s", userResourceId, err)
+		return apiKey{}, err
+	}
+	if resp.Error != "" {
+		return apiKey{}, fmt.Errorf("unexpected API response: %s", resp.Error)
 	}
-	requestBody := bytes.NewBuffer(json_post_data)
+	return resp.ApiKey, nil
+}
 
-	log.Printf("req-body: %v", requestBody)
+// ownerKindOf returns the kind of the API key owner based on the prefix of its resource ID.
+func ownerKindOf(ownerId string) (string, error) {
+	if strings.HasPrefix(ownerId, serviceAccountIdPrefix) {
+		return ownerKindServiceAccount, nil
+	} else if strings.HasPrefix(ownerId, userIdPrefix) {
+		return ownerKindUser, nil
+	}
+	return "", fmt.Errorf("unknown kind of the API key owner %s: the owner ID must be of the form 'sa-' or 'u-'", ownerId)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, requestBody)
+// resolveApiKeyOwnerIntegerId returns the integer ID the legacy API expects for service accounts.
+// Users are identified by their resource ID only, so 0 is returned for them once they're known to exist.
+func resolveApiKeyOwnerIntegerId(ctx context.Context, c *Client, ownerId string) (int, error) {
+	ownerKind, err := ownerKindOf(ownerId)
 	if err != nil {
-		return diag.FromErr(err)
+		return 0, err
+	}
+	if ownerKind == ownerKindServiceAccount {
+		return saResourceIdToSaIntegerId(c, ownerId)
+	}
+	_, resp, err := c.iamClient.UsersIamV2Api.GetIamV2User(c.iamApiContext(ctx), ownerId).Execute()
+	if err != nil {
+		if HasStatusNotFound(resp) || (HasStatusForbidden(resp) && !HasStatusForbiddenDueToInvalidAPIKey(resp)) {
+			return 0, fmt.Errorf("the user with resource ID=%s was not found", ownerId)
+		}
+		return 0, err
+	}
+	return 0, nil
+}
+
+// setCreatedApiKeyAttributes stores the key and the secret of a newly created API key.
+// When pgpEntity is set, only the encrypted secret is written to the state.
+func setCreatedApiKeyAttributes(d *schema.ResourceData, createdApiKey apiKey, pgpEntity *openpgp.Entity) error {
+	if err := d.Set("key", createdApiKey.Key); err != nil {
+		return err
 	}
+	if err := d.Set(paramCreatedAt, createdApiKey.Created); err != nil {
+		return err
+	}
+	if err := setApiKeyRotateAt(d); err != nil {
+		return err
+	}
+
+	if pgpEntity == nil {
+		return d.Set("secret", createdApiKey.Secret)
+	}
+
+	encryptedSecret, fingerprint, err := encryptWithPgpKey(pgpEntity, createdApiKey.Secret)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("secret", ""); err != nil {
+		return err
+	}
+	if err := d.Set(paramEncryptedSecret, encryptedSecret); err != nil {
+		return err
+	}
+	return d.Set(paramKeyFingerprint, fingerprint)
+}
 
-	req.Header.Add("Content-type", "application/json")
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
+func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[INFO] API key read for %s", d.Id())
+	c := m.(*Client)
+	environmentId := d.Get("environment_id").(string)
 
-	r, err := client.Do(req)
+	key, resp, err := executeApiKeyLookup(ctx, c, environmentId, d.Id())
 	if err != nil {
+		log.Printf("[WARN] API key get failed for id %s, %v, %s", d.Id(), resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] API key with id=%s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
-	defer r.Body.Close()
 
-	if r.StatusCode != 200 {
-		body, _ := ioutil.ReadAll(r.Body)
-		log.Printf("body: %v", body)
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
+	return diag.FromErr(setApiKeyAttributes(d, key))
+}
+
+func setApiKeyAttributes(d *schema.ResourceData, key apiKey) error {
+	if err := d.Set("key", key.Key); err != nil {
+		return err
+	}
+	if err := d.Set(paramCreatedAt, key.Created); err != nil {
+		return err
+	}
+	if err := setApiKeyRotateAt(d); err != nil {
+		return err
+	}
+	if err := d.Set("description", key.Description); err != nil {
+		return err
+	}
+	if err := d.Set("owner_id", key.UserResourceId); err != nil {
+		return err
 	}
+	if ownerKind, err := ownerKindOf(key.UserResourceId); err == nil {
+		if err := d.Set(paramOwnerKind, ownerKind); err != nil {
+			return err
+		}
+	}
+	resourceId, resourceType := "", apiKeyResourceTypeCloud
+	if len(key.LogicalClusters) > 0 {
+		resourceId, resourceType = key.LogicalClusters[0].Id, key.LogicalClusters[0].Type
+	}
+	if err := d.Set("resource_id", resourceId); err != nil {
+		return err
+	}
+	if err := d.Set("resource_type", resourceType); err != nil {
+		return err
+	}
+	// Cloud API keys are organization-level, so the environment they were created with is kept as is
+	if key.AccountId != "" && (resourceType != apiKeyResourceTypeCloud || d.Get("environment_id").(string) == "") {
+		if err := d.Set("environment_id", key.AccountId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	var resp response
+// apiKeyQuery scopes requests of the API keys API to an environment, unless it's a Cloud API key without one.
+func apiKeyQuery(environmentId string) url.Values {
+	if environmentId == "" {
+		return nil
+	}
+	return url.Values{"account_id": {environmentId}}
+}
 
-	err = json.NewDecoder(r.Body).Decode(&resp)
+// executeApiKeyLookup finds an API key by its key ID. The legacy API only exposes API keys by their integer ID,
+// so the key is looked up in the list of the environment's API keys (or of all API keys of the organization
+// when environmentId is empty). An API key that is missing from the list is reported with a synthetic
+// http.StatusNotFound response.
+func executeApiKeyLookup(ctx context.Context, c *Client, environmentId, keyId string) (apiKey, *http.Response, error) {
+	var resp listApiKeysResponse
+	r, err := c.legacyClient.Get(ctx, "/api_keys", apiKeyQuery(environmentId), &resp)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiKey{}, r, err
 	}
 	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+		return apiKey{}, r, fmt.Errorf("unexpected API response: %s", resp.Error)
+	}
+
+	for _, key := range resp.ApiKeys {
+		if key.Key == keyId {
+			return key, r, nil
+		}
+	}
+	if environmentId == "" {
+		return apiKey{}, &http.Response{StatusCode: http.StatusNotFound}, fmt.Errorf("the API key %s was not found", keyId)
+	}
+	return apiKey{}, &http.Response{StatusCode: http.StatusNotFound}, fmt.Errorf("the API key %s was not found in environment %s", keyId, environmentId)
+}
+
+// setApiKeyRotateAt sets rotate_at from created_at and rotation.rotate_after.
+func setApiKeyRotateAt(d *schema.ResourceData) error {
+	rotateAt, err := apiKeyRotateAt(d.Get(paramRotationRotateAfter).(string), d.Get(paramCreatedAt).(string))
+	if err != nil {
+		return err
+	}
+	return d.Set(paramRotateAt, rotateAt)
+}
+
+// resourceApiKeyCustomizeDiff plans a rotation once the current API Key is older than rotation.rotate_after,
+// and plans the revocation of the previous API Key on the apply that follows a rotation.
+// Whether the rotation is due depends on the time of the plan, which is why rotate_at is exposed.
+func resourceApiKeyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if err := customizeApiKeyResourceTypeDiff(diff); err != nil {
+		return err
+	}
+
+	// Fail at plan time rather than after the API key has been created
+	if diff.HasChange(paramPgpKey) && diff.NewValueKnown(paramPgpKey) {
+		if _, err := retrieveApiKeyPgpKey(ctx, diff.Get(paramPgpKey).(string)); err != nil {
+			return fmt.Errorf("invalid %s: %s", paramPgpKey, err)
+		}
 	}
 
-	body, _ := ioutil.ReadAll(r.Body)
-	log.Printf("resp-body-raw: %v", body)
+	if diff.Id() == "" {
+		return nil
+	}
 
-	log.Printf("resp-body: %v", resp)
+	isRotationDue, err := apiKeyRotationIsDue(diff.Get(paramRotationRotateAfter).(string), diff.Get(paramCreatedAt).(string), time.Now())
+	if err != nil {
+		return err
+	}
+	if isRotationDue {
+		log.Printf("[INFO] API key %s is due for rotation", diff.Id())
+		for _, attribute := range []string{"key", "secret", paramEncryptedSecret, paramKeyFingerprint, paramCreatedAt, paramRotateAt, paramPreviousKey, paramPreviousSecret, paramPreviousEncryptedSecret} {
+			if err := diff.SetNewComputed(attribute); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-	d.Set("secret", resp.ApiKey.Secret)
-	d.Set("key", resp.ApiKey.Key)
-	d.SetId(resp.ApiKey.Key)
+	rotateAt, err := apiKeyRotateAt(diff.Get(paramRotationRotateAfter).(string), diff.Get(paramCreatedAt).(string))
+	if err != nil {
+		return err
+	}
+	if diff.Get(paramRotateAt).(string) != rotateAt {
+		if err := diff.SetNew(paramRotateAt, rotateAt); err != nil {
+			return err
+		}
+	}
 
-	return diags
+	if diff.Get(paramPreviousKey).(string) != "" {
+		if err := diff.SetNew(paramPreviousKey, ""); err != nil {
+			return err
+		}
+		if err := diff.SetNew(paramPreviousSecret, ""); err != nil {
+			return err
+		}
+		if err := diff.SetNew(paramPreviousEncryptedSecret, ""); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+// customizeApiKeyResourceTypeDiff checks at plan time that resource_type and resource_id are consistent,
+// and infers resource_type from resource_id when it's omitted.
+func customizeApiKeyResourceTypeDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown("resource_id") || !diff.NewValueKnown("resource_type") {
+		return nil
+	}
+	configuredResourceType := ""
+	if rawConfig := diff.GetRawConfig(); !rawConfig.IsNull() && rawConfig.Type().IsObjectType() {
+		if rawResourceType := rawConfig.GetAttr("resource_type"); rawResourceType.IsKnown() && !rawResourceType.IsNull() {
+			configuredResourceType = rawResourceType.AsString()
+		}
+	} else {
+		configuredResourceType = diff.Get("resource_type").(string)
+	}
+	resourceType, err := apiKeyResourceType(configuredResourceType, diff.Get("resource_id").(string))
+	if err != nil {
+		return err
+	}
+	if resourceType != apiKeyResourceTypeCloud && diff.NewValueKnown("environment_id") && diff.Get("environment_id").(string) == "" {
+		return fmt.Errorf("environment_id must be set for %s API keys", resourceType)
+	}
+	if diff.Get("resource_type").(string) != resourceType {
+		return diff.SetNew("resource_type", resourceType)
+	}
+	return nil
+}
+
+// apiKeyResourceType validates the resource type of an API key against its resource ID.
+// An empty resourceType is inferred from resourceId: no resource ID means a Cloud API key.
+func apiKeyResourceType(resourceType, resourceId string) (string, error) {
+	if resourceType == "" {
+		if resourceId == "" {
+			return apiKeyResourceTypeCloud, nil
+		}
+		for prefix, inferredResourceType := range apiKeyResourceTypeByResourceIdPrefix {
+			if strings.HasPrefix(resourceId, prefix) {
+				return inferredResourceType, nil
+			}
+		}
+		return "", fmt.Errorf("could not infer resource_type from resource_id %s, set resource_type to one of %v", resourceId, acceptedApiKeyResourceTypes)
+	}
+	if resourceType == apiKeyResourceTypeCloud && resourceId != "" {
+		return "", fmt.Errorf("resource_id must not be set for %s API keys", apiKeyResourceTypeCloud)
+	}
+	if resourceType != apiKeyResourceTypeCloud && resourceId == "" {
+		return "", fmt.Errorf("resource_id must be set for %s API keys", resourceType)
+	}
+	return resourceType, nil
+}
+
+// apiKeyRotationIsDue reports whether an API Key created at createdAt (RFC 3339) has to be rotated at now.
+// An empty rotateAfter means that rotation is disabled.
+func apiKeyRotationIsDue(rotateAfter, createdAt string, now time.Time) (bool, error) {
+	rotationTime, err := apiKeyRotationTime(rotateAfter, createdAt)
+	if err != nil || rotationTime.IsZero() {
+		return false, err
+	}
+	return !now.Before(rotationTime), nil
+}
+
+// apiKeyRotateAt returns the time (RFC 3339) from which an API Key created at createdAt has to be rotated,
+// or an empty string when rotation is disabled.
+func apiKeyRotateAt(rotateAfter, createdAt string) (string, error) {
+	rotationTime, err := apiKeyRotationTime(rotateAfter, createdAt)
+	if err != nil || rotationTime.IsZero() {
+		return "", err
+	}
+	return rotationTime.Format(time.RFC3339), nil
+}
+
+// apiKeyRotationTime returns the zero time when rotation is disabled.
+func apiKeyRotationTime(rotateAfter, createdAt string) (time.Time, error) {
+	if rotateAfter == "" || createdAt == "" {
+		return time.Time{}, nil
+	}
+	rotateAfterDuration, err := time.ParseDuration(rotateAfter)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing %s: %s", paramRotateAfter, err)
+	}
+	created, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing the creation time of the API key: %s", err)
+	}
+	return created.Add(rotateAfterDuration), nil
+}
+
+func resourceApiKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	environmentId := d.Get("environment_id").(string)
+	oldPreviousKey, _ := d.GetChange(paramPreviousKey)
+
+	if d.HasChange("key") {
+		currentKey := d.Id()
+		currentSecret, _ := d.GetChange("secret")
+		currentEncryptedSecret, _ := d.GetChange(paramEncryptedSecret)
+
+		pgpEntity, err := retrieveApiKeyPgpKey(ctx, d.Get(paramPgpKey).(string))
+		if err != nil {
+			return diag.Errorf("error rotating API key (%s): %s", currentKey, err)
+		}
+		rotatedApiKey, err := executeApiKeyCreate(ctx, c, d)
+		if err != nil {
+			return diag.Errorf("error rotating API key (%s): %s", currentKey, err)
+		}
+		d.SetId(rotatedApiKey.Key)
+		log.Printf("[INFO] API key %s was rotated, the new API key is %s", currentKey, rotatedApiKey.Key)
+		if err := setCreatedApiKeyAttributes(d, rotatedApiKey, pgpEntity); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if oldPreviousKey.(string) != "" {
+			if err := executeApiKeyDelete(ctx, c, environmentId, oldPreviousKey.(string)); err != nil {
+				return diag.Errorf("error revoking previous API key (%s): %s", oldPreviousKey, err)
+			}
+		}
+
+		if d.Get(paramRotationKeepPrevious).(int) > 0 {
+			if err := d.Set(paramPreviousKey, currentKey); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(paramPreviousSecret, currentSecret); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(paramPreviousEncryptedSecret, currentEncryptedSecret); err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			if err := executeApiKeyDelete(ctx, c, environmentId, currentKey); err != nil {
+				return diag.Errorf("error revoking rotated API key (%s): %s", currentKey, err)
+			}
+			if err := d.Set(paramPreviousKey, ""); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(paramPreviousSecret, ""); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(paramPreviousEncryptedSecret, ""); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		// A rotated API key is created with the updated description already
+		if d.HasChange("description") {
+			if err := executeApiKeyUpdate(ctx, c, environmentId, d.Id(), d.Get("description").(string)); err != nil {
+				return diag.Errorf("error updating API key (%s): %s", d.Id(), err)
+			}
+			log.Printf("[INFO] API key %s description was updated", d.Id())
+		}
+		if d.HasChange(paramPreviousKey) && oldPreviousKey.(string) != "" {
+			if err := executeApiKeyDelete(ctx, c, environmentId, oldPreviousKey.(string)); err != nil {
+				return diag.Errorf("error revoking previous API key (%s): %s", oldPreviousKey, err)
+			}
+			log.Printf("[INFO] Previous API key %s was revoked", oldPreviousKey)
+		}
+	}
+
+	if d.HasChange("key") {
+		// Skip reading the API key back: a freshly rotated API key might not be listed yet
+		return nil
+	}
+	return resourceApiKeyRead(ctx, d, m)
+}
+
+// resourceApiKeyImport accepts either '<key ID>' or '<env ID>/<key ID>'.
+// The secret can't be read back from Confluent Cloud, so it is only populated when API_KEY_SECRET is set.
+// API_KEY_PGP_KEY sets pgp_key of the imported API key, so that its secret is stored encrypted like the one of a created API key.
+func resourceApiKeyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[INFO] API key import for %s", d.Id())
+	c := m.(*Client)
+
+	environmentId := ""
+	keyId := d.Id()
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) == 2 {
+		environmentId = parts[0]
+		keyId = parts[1]
+	} else if len(parts) != 1 {
+		return nil, fmt.Errorf("invalid format for API key import: expected '<key ID>' or '<env ID>/<key ID>'")
+	}
+
+	key, _, err := executeApiKeyLookup(ctx, c, environmentId, keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(key.Key)
+	if err := setApiKeyAttributes(d, key); err != nil {
+		return nil, err
+	}
+	pgpKey := getEnv(apiKeyImportPgpKeyEnvVar, "")
+	if err := d.Set(paramPgpKey, pgpKey); err != nil {
+		return nil, err
+	}
+	pgpEntity, err := retrieveApiKeyPgpKey(ctx, pgpKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", apiKeyImportPgpKeyEnvVar, err)
+	}
+	if key.Secret = getEnv(apiKeyImportSecretEnvVar, ""); key.Secret != "" {
+		if err := setCreatedApiKeyAttributes(d, key, pgpEntity); err != nil {
+			return nil, err
+		}
+	} else {
+		log.Printf("[WARN] %s is not set, the secret of the imported API key %s will be empty", apiKeyImportSecretEnvVar, key.Key)
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	log.Printf("[INFO] API key delete for %s", d.Id())
+	c := m.(*Client)
+	environmentId := d.Get("environment_id").(string)
+
+	if previousKey := d.Get(paramPreviousKey).(string); previousKey != "" {
+		if err := executeApiKeyDelete(ctx, c, environmentId, previousKey); err != nil {
+			return diag.Errorf("error deleting previous API key (%s), err: %s", previousKey, err)
+		}
+	}
+
+	if err := executeApiKeyDelete(ctx, c, environmentId, d.Id()); err != nil {
+		return diag.Errorf("error deleting API key (%s), err: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] API key %s was deleted successfully", d.Id())
+
+	return nil
+}
+
+// executeApiKeyUpdate updates the mutable fields of an API key, which is just its description.
+func executeApiKeyUpdate(ctx context.Context, c *Client, environmentId, keyId, description string) error {
+	type apiKeyRequest struct {
+		Id          int    `json:"id"`
+		AccountId   string `json:"accountId,omitempty"`
+		Description string `json:"description"`
+	}
+
+	type request struct {
+		ApiKey apiKeyRequest `json:"apiKey"`
+	}
+
+	key, _, err := executeApiKeyLookup(ctx, c, environmentId, keyId)
+	if err != nil {
+		return err
+	}
+
+	updateRequest := request{apiKeyRequest{Id: key.Id, AccountId: environmentId, Description: description}}
+	var resp response
+	_, err = c.legacyClient.Put(ctx, fmt.Sprintf("/api_keys/%d", key.Id), apiKeyQuery(environmentId), updateRequest, &resp)
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("unexpected API response: %s", resp.Error)
+	}
+	return nil
+}
+
+// executeApiKeyDelete revokes an API key. An API key that doesn't exist anymore is considered revoked.
+func executeApiKeyDelete(ctx context.Context, c *Client, environmentId, keyId string) error {
+	type apiKeyRequest struct {
+		Id        int    `json:"id"`
+		AccountId string `json:"accountId,omitempty"`
+	}
+
+	type request struct {
+		ApiKey apiKeyRequest `json:"apiKey"`
+	}
+
+	key, resp, err := executeApiKeyLookup(ctx, c, environmentId, keyId)
+	if err != nil {
+		if HasStatusNotFound(resp) {
+			log.Printf("[WARN] API key with id=%s is already deleted", keyId)
+			return nil
+		}
+		return err
+	}
+
+	deleteRequest := request{apiKeyRequest{Id: key.Id, AccountId: environmentId}}
+	resp, err = c.legacyClient.Delete(ctx, fmt.Sprintf("/api_keys/%d", key.Id), apiKeyQuery(environmentId), deleteRequest, nil)
+	if err != nil && !HasStatusNotFound(resp) {
+		return err
+	}
+	return nil
 }
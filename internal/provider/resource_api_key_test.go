@@ -0,0 +1,397 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	iamv1 "github.com/confluentinc/ccloud-sdk-go-v2/iam/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLegacyClient returns a Client whose legacy client sends requests to a test server served by handler.
+func newTestLegacyClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{legacyClient: NewLegacyClient(server.URL, "test-user-agent", "foo", "bar")}
+}
+
+// newTestApiKeyClient is newTestLegacyClient() with an IAM v1 client, which resolves the integer IDs of service accounts.
+func newTestApiKeyClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	iamV1Cfg := iamv1.NewConfiguration()
+	iamV1Cfg.Servers[0].URL = server.URL
+	return &Client{
+		legacyClient: NewLegacyClient(server.URL, "test-user-agent", "foo", "bar"),
+		iamV1Client:  iamv1.NewAPIClient(iamV1Cfg),
+		apiKey:       "foo",
+		apiSecret:    "bar",
+	}
+}
+
+// apiKeyUpdateData returns the ResourceData that is passed to resourceApiKeyUpdate() when config is applied to an API key with state.
+func apiKeyUpdateData(t *testing.T, state map[string]string, config map[string]interface{}) *schema.ResourceData {
+	instanceState := &terraform.InstanceState{ID: state["id"], Attributes: state}
+	diff, err := resourceApiKey().Diff(context.Background(), instanceState, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	d, err := schema.InternalMap(resourceApiKey().Schema).Data(instanceState, diff)
+	require.NoError(t, err)
+	return d
+}
+
+const testApiKeysResponse = `{"api_keys": [{"id": 123, "key": "ABCDEFGH", "account_id": "env-abc123", "description": "CI key", "user_resource_id": "sa-abc123", "created": "2022-03-28T00:35:19.860568Z", "logical_clusters": [{"id": "lkc-abc123", "type": "kafka"}]}]}`
+
+func TestResourceApiKeyRead(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api_keys", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testApiKeysResponse))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceApiKey().Schema, map[string]interface{}{"environment_id": "env-abc123"})
+	d.SetId("ABCDEFGH")
+	require.Empty(t, resourceApiKeyRead(context.Background(), d, c))
+	require.Equal(t, "ABCDEFGH", d.Id())
+	require.Equal(t, "CI key", d.Get("description"))
+	require.Equal(t, "sa-abc123", d.Get("owner_id"))
+	require.Equal(t, "lkc-abc123", d.Get("resource_id"))
+	require.Equal(t, apiKeyResourceTypeKafka, d.Get("resource_type"))
+
+	// An API key that was revoked outside of Terraform is removed from the state
+	d.SetId("IJKLMNOP")
+	require.Empty(t, resourceApiKeyRead(context.Background(), d, c))
+	require.Empty(t, d.Id())
+}
+
+func TestResourceApiKeyReadCloudApiKey(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api_keys", r.URL.Path)
+		// Cloud API keys without an environment are looked up across the organization
+		require.Empty(t, r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"api_keys": [{"id": 456, "key": "QRSTUVWX", "account_id": "", "user_resource_id": "sa-abc123", "created": "2022-03-28T00:35:19.860568Z", "logical_clusters": []}]}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceApiKey().Schema, map[string]interface{}{"resource_type": apiKeyResourceTypeCloud})
+	d.SetId("QRSTUVWX")
+	require.Empty(t, resourceApiKeyRead(context.Background(), d, c))
+	require.Equal(t, "QRSTUVWX", d.Id())
+	require.Empty(t, d.Get("environment_id"))
+	require.Empty(t, d.Get("resource_id"))
+	require.Equal(t, apiKeyResourceTypeCloud, d.Get("resource_type"))
+}
+
+func TestResourceApiKeyImport(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api_keys", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testApiKeysResponse))
+	})
+	_ = os.Setenv(apiKeyImportSecretEnvVar, "imported-secret")
+	defer func() {
+		_ = os.Unsetenv(apiKeyImportSecretEnvVar)
+		_ = os.Unsetenv(apiKeyImportPgpKeyEnvVar)
+	}()
+
+	d := resourceApiKey().Data(nil)
+	d.SetId("env-abc123/ABCDEFGH")
+	imported, err := resourceApiKeyImport(context.Background(), d, c)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, "ABCDEFGH", d.Id())
+	require.Equal(t, "env-abc123", d.Get("environment_id"))
+	require.Equal(t, "imported-secret", d.Get("secret"))
+	require.Empty(t, d.Get(paramPgpKey))
+	require.Empty(t, d.Get(paramEncryptedSecret))
+
+	// With API_KEY_PGP_KEY, the secret is stored the same way as the one of an API key created with pgp_key
+	entity, err := openpgp.NewEntity("Test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	var publicKey bytes.Buffer
+	require.NoError(t, entity.Serialize(&publicKey))
+	pgpKey := base64.StdEncoding.EncodeToString(publicKey.Bytes())
+	_ = os.Setenv(apiKeyImportPgpKeyEnvVar, pgpKey)
+
+	d = resourceApiKey().Data(nil)
+	d.SetId("env-abc123/ABCDEFGH")
+	_, err = resourceApiKeyImport(context.Background(), d, c)
+	require.NoError(t, err)
+	require.Equal(t, pgpKey, d.Get(paramPgpKey))
+	require.Empty(t, d.Get("secret"))
+	require.NotEmpty(t, d.Get(paramEncryptedSecret))
+	require.Equal(t, hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]), d.Get(paramKeyFingerprint))
+
+	_ = os.Setenv(apiKeyImportPgpKeyEnvVar, "not base64!")
+	d = resourceApiKey().Data(nil)
+	d.SetId("env-abc123/ABCDEFGH")
+	_, err = resourceApiKeyImport(context.Background(), d, c)
+	require.Error(t, err)
+}
+
+func TestSetApiKeyAttributesKeepsEnvironmentOfCloudApiKey(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceApiKey().Schema, map[string]interface{}{"environment_id": "env-abc123"})
+	require.NoError(t, setApiKeyAttributes(d, apiKey{Key: "QRSTUVWX", AccountId: "env-def456", UserResourceId: "sa-abc123"}))
+	require.Equal(t, "env-abc123", d.Get("environment_id"))
+
+	require.NoError(t, setApiKeyAttributes(d, apiKey{Key: "ABCDEFGH", AccountId: "env-def456", UserResourceId: "sa-abc123", LogicalClusters: []logicalCluster{{Id: "lkc-abc123", Type: apiKeyResourceTypeKafka}}}))
+	require.Equal(t, "env-def456", d.Get("environment_id"))
+}
+
+func TestExecuteApiKeyDelete(t *testing.T) {
+	deleteCount := 0
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleteCount++
+			require.Equal(t, "/api_keys/123", r.URL.Path)
+			var body map[string]map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, float64(123), body["apiKey"]["id"])
+			require.Equal(t, "env-abc123", body["apiKey"]["accountId"])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testApiKeysResponse))
+	})
+
+	require.NoError(t, executeApiKeyDelete(context.Background(), c, "env-abc123", "ABCDEFGH"))
+	require.Equal(t, 1, deleteCount)
+
+	// An API key that doesn't exist anymore is considered revoked
+	require.NoError(t, executeApiKeyDelete(context.Background(), c, "env-abc123", "IJKLMNOP"))
+	require.Equal(t, 1, deleteCount)
+}
+
+func TestResourceApiKeyUpdateRotatesApiKey(t *testing.T) {
+	tests := []struct {
+		name            string
+		keepPrevious    int
+		previousKey     string
+		revokedPaths    []string
+		wantPreviousKey string
+	}{
+		{name: "revoke the rotated API key", keepPrevious: 0, revokedPaths: []string{"/api_keys/123"}},
+		{name: "keep the rotated API key", keepPrevious: 1, wantPreviousKey: "ABCDEFGH"},
+		{name: "revoke the previous API key", keepPrevious: 1, previousKey: "IJKLMNOP", revokedPaths: []string{"/api_keys/122"}, wantPreviousKey: "ABCDEFGH"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var createdKeys []string
+			var revokedPaths []string
+			c := newTestApiKeyClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/service_accounts":
+					_, _ = w.Write([]byte(`{"users": [{"id": 12345, "resource_id": "sa-abc123"}]}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/api_keys":
+					_, _ = w.Write([]byte(`{"api_keys": [{"id": 123, "key": "ABCDEFGH", "account_id": "env-abc123"}, {"id": 122, "key": "IJKLMNOP", "account_id": "env-abc123"}]}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/api_keys":
+					var body map[string]map[string]interface{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					require.Equal(t, "env-abc123", body["apiKey"]["accountId"])
+					require.Equal(t, "CI key", body["apiKey"]["description"])
+					require.Equal(t, float64(12345), body["apiKey"]["userId"])
+					require.Equal(t, "sa-abc123", body["apiKey"]["userResourceId"])
+					require.Equal(t, []interface{}{map[string]interface{}{"id": "lkc-abc123", "type": "kafka"}}, body["apiKey"]["logicalClusters"])
+					createdKeys = append(createdKeys, "QRSTUVWX")
+					_, _ = w.Write([]byte(`{"api_key": {"id": 124, "key": "QRSTUVWX", "secret": "new-secret", "account_id": "env-abc123", "created": "2022-04-27T00:35:19.860568Z"}}`))
+				case r.Method == http.MethodDelete:
+					revokedPaths = append(revokedPaths, r.URL.Path)
+				default:
+					t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			d := apiKeyUpdateData(t, map[string]string{
+				"id":                        "ABCDEFGH",
+				"key":                       "ABCDEFGH",
+				"secret":                    "old-secret",
+				"environment_id":            "env-abc123",
+				"owner_id":                  "sa-abc123",
+				"resource_id":               "lkc-abc123",
+				"resource_type":             "kafka",
+				"description":               "CI key",
+				"created_at":                "2022-03-28T00:35:19.860568Z",
+				"rotation.#":                "1",
+				"rotation.0.rotate_after":   "720h",
+				"rotation.0.keep_previous":  fmt.Sprint(test.keepPrevious),
+				"rotate_at":                 "2022-04-27T00:35:19Z",
+				"previous_key":              test.previousKey,
+				"previous_secret":           "",
+				"previous_encrypted_secret": "",
+			}, map[string]interface{}{
+				"environment_id": "env-abc123",
+				"owner_id":       "sa-abc123",
+				"resource_id":    "lkc-abc123",
+				"resource_type":  "kafka",
+				"description":    "CI key",
+				"rotation":       []interface{}{map[string]interface{}{"rotate_after": "720h", "keep_previous": test.keepPrevious}},
+			})
+			require.True(t, d.HasChange("key"))
+
+			require.Empty(t, resourceApiKeyUpdate(context.Background(), d, c))
+			require.Equal(t, []string{"QRSTUVWX"}, createdKeys)
+			require.Equal(t, test.revokedPaths, revokedPaths)
+			require.Equal(t, "QRSTUVWX", d.Id())
+			require.Equal(t, "QRSTUVWX", d.Get("key"))
+			require.Equal(t, "new-secret", d.Get("secret"))
+			require.Equal(t, "2022-05-27T00:35:19Z", d.Get(paramRotateAt))
+			require.Equal(t, test.wantPreviousKey, d.Get(paramPreviousKey))
+			if test.wantPreviousKey != "" {
+				require.Equal(t, "old-secret", d.Get(paramPreviousSecret))
+			} else {
+				require.Empty(t, d.Get(paramPreviousSecret))
+			}
+		})
+	}
+}
+
+func TestResourceApiKeyUpdateDescription(t *testing.T) {
+	description := "CI key"
+	updateCount := 0
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api_keys":
+			require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+			_, _ = fmt.Fprintf(w, `{"api_keys": [{"id": 123, "key": "ABCDEFGH", "account_id": "env-abc123", "description": %q, "user_resource_id": "sa-abc123", "created": "2022-03-28T00:35:19.860568Z", "logical_clusters": [{"id": "lkc-abc123", "type": "kafka"}]}]}`, description)
+		case r.Method == http.MethodPut && r.URL.Path == "/api_keys/123":
+			updateCount++
+			require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"apiKey": {"id": 123, "accountId": "env-abc123", "description": "Deployment key"}}`, string(body))
+			description = "Deployment key"
+			_, _ = w.Write([]byte(`{"api_key": {"id": 123, "key": "ABCDEFGH"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	d := apiKeyUpdateData(t, map[string]string{
+		"id":             "ABCDEFGH",
+		"key":            "ABCDEFGH",
+		"secret":         "secret",
+		"environment_id": "env-abc123",
+		"owner_id":       "sa-abc123",
+		"resource_id":    "lkc-abc123",
+		"resource_type":  "kafka",
+		"description":    "CI key",
+		"created_at":     "2022-03-28T00:35:19.860568Z",
+	}, map[string]interface{}{
+		"environment_id": "env-abc123",
+		"owner_id":       "sa-abc123",
+		"resource_id":    "lkc-abc123",
+		"resource_type":  "kafka",
+		"description":    "Deployment key",
+	})
+	require.False(t, d.HasChange("key"))
+
+	require.Empty(t, resourceApiKeyUpdate(context.Background(), d, c))
+	require.Equal(t, 1, updateCount)
+	require.Equal(t, "ABCDEFGH", d.Id())
+	require.Equal(t, "secret", d.Get("secret"))
+	require.Equal(t, "Deployment key", d.Get("description"))
+}
+
+func TestApiKeyRotationIsDue(t *testing.T) {
+	createdAt := "2022-03-28T00:35:19.860568Z"
+	created, err := time.Parse(time.RFC3339Nano, createdAt)
+	require.NoError(t, err)
+
+	isDue, err := apiKeyRotationIsDue("720h", createdAt, created.Add(719*time.Hour))
+	require.NoError(t, err)
+	require.False(t, isDue)
+
+	isDue, err = apiKeyRotationIsDue("720h", createdAt, created.Add(720*time.Hour))
+	require.NoError(t, err)
+	require.True(t, isDue)
+
+	// Rotation is disabled
+	isDue, err = apiKeyRotationIsDue("", createdAt, created.Add(10000*time.Hour))
+	require.NoError(t, err)
+	require.False(t, isDue)
+
+	_, err = apiKeyRotationIsDue("720h", "yesterday", created)
+	require.Error(t, err)
+}
+
+func TestApiKeyRotateAt(t *testing.T) {
+	rotateAt, err := apiKeyRotateAt("720h", "2022-03-28T00:35:19.860568Z")
+	require.NoError(t, err)
+	require.Equal(t, "2022-04-27T00:35:19Z", rotateAt)
+
+	// Rotation is disabled
+	rotateAt, err = apiKeyRotateAt("", "2022-03-28T00:35:19.860568Z")
+	require.NoError(t, err)
+	require.Empty(t, rotateAt)
+}
+
+func TestOwnerKindOf(t *testing.T) {
+	ownerKind, err := ownerKindOf("sa-abc123")
+	require.NoError(t, err)
+	require.Equal(t, ownerKindServiceAccount, ownerKind)
+
+	ownerKind, err = ownerKindOf("u-abc123")
+	require.NoError(t, err)
+	require.Equal(t, ownerKindUser, ownerKind)
+
+	_, err = ownerKindOf("env-abc123")
+	require.Error(t, err)
+}
+
+func TestApiKeyResourceType(t *testing.T) {
+	resourceType, err := apiKeyResourceType("", "lkc-abc123")
+	require.NoError(t, err)
+	require.Equal(t, apiKeyResourceTypeKafka, resourceType)
+
+	resourceType, err = apiKeyResourceType("", "lsrc-abc123")
+	require.NoError(t, err)
+	require.Equal(t, apiKeyResourceTypeSchemaRegistry, resourceType)
+
+	resourceType, err = apiKeyResourceType("", "")
+	require.NoError(t, err)
+	require.Equal(t, apiKeyResourceTypeCloud, resourceType)
+
+	resourceType, err = apiKeyResourceType(apiKeyResourceTypeKsql, "lksqlc-abc123")
+	require.NoError(t, err)
+	require.Equal(t, apiKeyResourceTypeKsql, resourceType)
+
+	_, err = apiKeyResourceType(apiKeyResourceTypeCloud, "lkc-abc123")
+	require.Error(t, err)
+
+	_, err = apiKeyResourceType(apiKeyResourceTypeKafka, "")
+	require.Error(t, err)
+
+	_, err = apiKeyResourceType("", "foo-abc123")
+	require.Error(t, err)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
 )
 
@@ -39,6 +41,7 @@ const (
 	paramHttpEndpoint         = "http_endpoint"
 	paramCku                  = "cku"
 	paramRbacCrn              = "rbac_crn"
+	paramNetwork              = "network"
 
 	stateInProgress = "in-progress"
 	stateDone       = "done"
@@ -124,6 +127,7 @@ func kafkaResource() *schema.Resource {
 					"confluentcloud_role_binding's crn_pattern.",
 			},
 			paramEnvironment: environmentSchema(),
+			paramNetwork:     kafkaClusterNetworkSchema(),
 		},
 	}
 }
@@ -213,6 +217,68 @@ func executeKafkaCreate(ctx context.Context, c *Client, cluster *cmk.CmkV2Cluste
 	return req.Execute()
 }
 
+// kafkaClusterNetworkSpec is the part of a cmk v2 cluster that ccloud-sdk-go-v2/cmk doesn't model yet,
+// so requests and responses that involve spec.network are sent as raw JSON.
+// TODO: remove this workaround once ccloud-sdk-go-v2/cmk exposes spec.network
+type kafkaClusterNetworkSpec struct {
+	Spec struct {
+		Network *cmk.ObjectReference `json:"network,omitempty"`
+	} `json:"spec"`
+}
+
+func kafkaClusterPath(clusterId string) string {
+	return fmt.Sprintf("/cmk/v2/clusters/%s", clusterId)
+}
+
+// cmkRawClient sends raw JSON requests with the endpoint, HTTP client, user agent and credentials of the cmk client.
+func cmkRawClient(ctx context.Context, c *Client) (*LegacyClient, error) {
+	cmkApiContext := c.cmkApiContext(ctx)
+	cfg := c.cmkClient.GetConfig()
+	endpoint, err := cfg.ServerURLWithContext(cmkApiContext, "ClustersCmkV2ApiService.CreateCmkV2Cluster")
+	if err != nil {
+		return nil, err
+	}
+	auth, _ := cmkApiContext.Value(cmk.ContextBasicAuth).(cmk.BasicAuth)
+	return newLegacyClientWithHttpClient(cfg.HTTPClient, endpoint, cfg.UserAgent, auth.UserName, auth.Password), nil
+}
+
+func executeKafkaCreateInNetwork(ctx context.Context, c *Client, cluster *cmk.CmkV2Cluster, networkId string) (cmk.CmkV2Cluster, *http.Response, error) {
+	client, err := cmkRawClient(ctx, c)
+	if err != nil {
+		return cmk.CmkV2Cluster{}, nil, err
+	}
+	specBytes, err := json.Marshal(cluster.Spec)
+	if err != nil {
+		return cmk.CmkV2Cluster{}, nil, err
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(specBytes, &spec); err != nil {
+		return cmk.CmkV2Cluster{}, nil, err
+	}
+	spec[paramNetwork] = cmk.ObjectReference{Id: networkId}
+
+	var createdCluster cmk.CmkV2Cluster
+	resp, err := client.Post(ctx, "/cmk/v2/clusters", nil, map[string]interface{}{"spec": spec}, &createdCluster)
+	return createdCluster, resp, err
+}
+
+// executeKafkaNetworkRead returns the ID of the network the Kafka cluster is attached to, or "" for public clusters.
+func executeKafkaNetworkRead(ctx context.Context, c *Client, environmentId string, clusterId string) (string, *http.Response, error) {
+	client, err := cmkRawClient(ctx, c)
+	if err != nil {
+		return "", nil, err
+	}
+	var cluster kafkaClusterNetworkSpec
+	resp, err := client.Get(ctx, kafkaClusterPath(clusterId), url.Values{"environment": {environmentId}}, &cluster)
+	if err != nil {
+		return "", resp, err
+	}
+	if cluster.Spec.Network == nil {
+		return "", resp, nil
+	}
+	return cluster.Spec.Network.Id, resp, nil
+}
+
 func kafkaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*Client)
 
@@ -260,7 +326,14 @@ func kafkaCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 	}
 	log.Printf("[DEBUG] Creating Kafka cluster with spec %s", specBytes)
 
-	kafka, resp, err := executeKafkaCreate(c.cmkApiContext(ctx), c, &cluster)
+	var kafka cmk.CmkV2Cluster
+	var resp *http.Response
+	if networkId := extractNetworkId(d); networkId != "" {
+		log.Printf("[DEBUG] Creating Kafka cluster in network %s", networkId)
+		kafka, resp, err = executeKafkaCreateInNetwork(ctx, c, &cluster, networkId)
+	} else {
+		kafka, resp, err = executeKafkaCreate(c.cmkApiContext(ctx), c, &cluster)
+	}
 	if err != nil {
 		log.Printf("[ERROR] Kafka cluster create failed %v, %v, %s", cluster, resp, err)
 		return createDiagnosticsWithDetails(err)
@@ -291,6 +364,28 @@ func extractClusterType(d *schema.ResourceData) string {
 	return ""
 }
 
+func extractNetworkId(d *schema.ResourceData) string {
+	networkData := d.Get(paramNetwork).([]interface{})
+	if len(networkData) == 0 || networkData[0] == nil {
+		return ""
+	}
+	return networkData[0].(map[string]interface{})[paramId].(string)
+}
+
+// isKafkaClusterInNetworkPossible returns false for Basic and Standard clusters, which only support public networking.
+func isKafkaClusterInNetworkPossible(cluster cmk.CmkV2Cluster) bool {
+	return cluster.Spec != nil && cluster.Spec.Config != nil && cluster.Spec.Config.CmkV2Dedicated != nil
+}
+
+func setNetworkId(networkId string, d *schema.ResourceData) error {
+	if networkId == "" {
+		return d.Set(paramNetwork, []interface{}{})
+	}
+	return d.Set(paramNetwork, []interface{}{map[string]interface{}{
+		paramId: networkId,
+	}})
+}
+
 func extractCku(d *schema.ResourceData) int32 {
 	// CKUs are only defined for dedicated clusters
 	if kafkaClusterTypeDedicated != extractClusterType(d) {
@@ -332,7 +427,7 @@ func kafkaImport(ctx context.Context, d *schema.ResourceData, meta interface{})
 	d.SetId(clusterId)
 	log.Printf("[INFO] Kafka import for %s", clusterId)
 
-	return readAndSetResourceConfigurationArguments(ctx, d, meta, environmentId, clusterId)
+	return readAndSetResourceConfigurationArguments(ctx, d, meta, environmentId, clusterId, true)
 }
 
 func executeKafkaRead(ctx context.Context, c *Client, environmentId string, clusterId string) (cmk.CmkV2Cluster, *http.Response, error) {
@@ -349,12 +444,13 @@ func kafkaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 		return createDiagnosticsWithDetails(err)
 	}
 
-	_, err = readAndSetResourceConfigurationArguments(ctx, d, meta, environmentId, clusterId)
+	// The network only needs to be read back for clusters that were created in one
+	_, err = readAndSetResourceConfigurationArguments(ctx, d, meta, environmentId, clusterId, extractNetworkId(d) != "")
 
 	return createDiagnosticsWithDetails(err)
 }
 
-func readAndSetResourceConfigurationArguments(ctx context.Context, d *schema.ResourceData, meta interface{}, environmentId, clusterId string) ([]*schema.ResourceData, error) {
+func readAndSetResourceConfigurationArguments(ctx context.Context, d *schema.ResourceData, meta interface{}, environmentId, clusterId string, readNetwork bool) ([]*schema.ResourceData, error) {
 	c := meta.(*Client)
 
 	cluster, resp, err := executeKafkaRead(c.cmkApiContext(ctx), c, environmentId, clusterId)
@@ -434,6 +530,16 @@ func readAndSetResourceConfigurationArguments(ctx context.Context, d *schema.Res
 	if err := d.Set(paramRbacCrn, rbacCrn); err != nil {
 		return nil, err
 	}
+	if readNetwork && isKafkaClusterInNetworkPossible(cluster) {
+		networkId, resp, err := executeKafkaNetworkRead(ctx, c, environmentId, clusterId)
+		if err != nil {
+			log.Printf("[ERROR] Kafka cluster network get failed for id %s, %v, %s", clusterId, resp, err)
+			return nil, err
+		}
+		if err := setNetworkId(networkId, d); err != nil {
+			return nil, err
+		}
+	}
 	if err := setEnvironmentId(environmentId, d); err != nil {
 		return nil, err
 	}
@@ -485,6 +591,27 @@ func dedicatedClusterSchema() *schema.Schema {
 	}
 }
 
+func kafkaClusterNetworkSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramId: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ID of the network, for example, `n-abc123`.",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^n-"), "the network ID must be of the form 'n-'"),
+				},
+			},
+		},
+		Optional:    true,
+		MaxItems:    1,
+		ForceNew:    true,
+		Description: "The network the Kafka cluster is attached to. Omit it to create a cluster with public networking.",
+	}
+}
+
 func ckuCheck(cku int32, availability string) error {
 	if cku < 1 && availability == singleZone {
 		return fmt.Errorf("single-zone dedicated clusters must have at least 1 CKU")
@@ -16,7 +16,9 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	cmk "github.com/confluentinc/ccloud-sdk-go-v2/cmk/v2"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
@@ -24,6 +26,7 @@ import (
 	"github.com/walkerus/go-wiremock"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -281,3 +284,64 @@ func testAccCheckClusterExists(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+// newTestCmkClient returns a Client whose cmk client sends requests to a test server served by handler.
+func newTestCmkClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg := cmk.NewConfiguration()
+	cfg.Servers[0].URL = server.URL
+	return &Client{cmkClient: cmk.NewAPIClient(cfg), apiKey: "foo", apiSecret: "bar"}
+}
+
+func TestExecuteKafkaCreateInNetwork(t *testing.T) {
+	c := newTestCmkClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/cmk/v2/clusters", r.URL.Path)
+		userName, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "foo", userName)
+		require.Equal(t, "bar", password)
+		var body map[string]map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "n-abc123", body["spec"]["network"].(map[string]interface{})["id"])
+		require.Equal(t, "TestCluster", body["spec"]["display_name"])
+		require.Equal(t, "env-abc123", body["spec"]["environment"].(map[string]interface{})["id"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "lkc-abc123", "spec": {"display_name": "TestCluster", "network": {"id": "n-abc123"}}}`))
+	})
+
+	spec := cmk.NewCmkV2ClusterSpec()
+	spec.SetDisplayName("TestCluster")
+	spec.SetEnvironment(cmk.ObjectReference{Id: "env-abc123"})
+	cluster, _, err := executeKafkaCreateInNetwork(context.Background(), c, &cmk.CmkV2Cluster{Spec: spec}, "n-abc123")
+	require.NoError(t, err)
+	require.Equal(t, "lkc-abc123", cluster.GetId())
+}
+
+func TestExecuteKafkaNetworkRead(t *testing.T) {
+	c := newTestCmkClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cmk/v2/clusters/lkc-private":
+			_, _ = w.Write([]byte(`{"id": "lkc-private", "spec": {"network": {"id": "n-abc123"}}}`))
+		case "/cmk/v2/clusters/lkc-public":
+			_, _ = w.Write([]byte(`{"id": "lkc-public", "spec": {}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+
+	networkId, _, err := executeKafkaNetworkRead(context.Background(), c, "env-abc123", "lkc-private")
+	require.NoError(t, err)
+	require.Equal(t, "n-abc123", networkId)
+
+	networkId, _, err = executeKafkaNetworkRead(context.Background(), c, "env-abc123", "lkc-public")
+	require.NoError(t, err)
+	require.Empty(t, networkId)
+
+	_, resp, err := executeKafkaNetworkRead(context.Background(), c, "env-abc123", "lkc-deleted")
+	require.Error(t, err)
+	require.True(t, HasStatusForbidden(resp))
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramStatement   = "statement"
+	paramProperties  = "properties"
+	paramDeleteTopic = "delete_topic"
+	paramSourceName  = "source_name"
+	paramSourceType  = "source_type"
+	paramQueryId     = "query_id"
+)
+
+// Only statements that create a stream or a table (and the persistent query that populates it) can be managed
+var ksqlCreateSourceStatementRegex = regexp.MustCompile(`(?is)^\s*CREATE\s+(OR\s+REPLACE\s+)?(SOURCE\s+)?(STREAM|TABLE)\s`)
+
+func resourceKsqlStatement() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ksqlStatementCreate,
+		ReadContext:   ksqlStatementRead,
+		UpdateContext: ksqlStatementUpdate,
+		DeleteContext: ksqlStatementDelete,
+		Schema: map[string]*schema.Schema{
+			paramHttpEndpoint: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The REST endpoint of the ksqlDB cluster, for example, `https://pksqlc-00000.us-central1.gcp.confluent.cloud:443`.",
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			paramCredentials: credentialsSchema(),
+			paramStatement: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The `CREATE STREAM` or `CREATE TABLE` statement to run.",
+				ValidateFunc: validation.StringMatch(ksqlCreateSourceStatementRegex, "the statement must be a CREATE STREAM or CREATE TABLE statement"),
+			},
+			paramProperties: {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The streams properties to run the statement with (e.g., `\"ksql.streams.auto.offset.reset\" = \"earliest\"`).",
+			},
+			paramDeleteTopic: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the Kafka topic of the stream or table on destroy.",
+			},
+			paramSourceName: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the stream or table the statement created.",
+			},
+			paramSourceType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the source the statement created, either `STREAM` or `TABLE`.",
+			},
+			paramQueryId: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the persistent query that populates the stream or table, if any.",
+			},
+		},
+	}
+}
+
+func ksqlRestClientFromResourceData(d *schema.ResourceData, meta interface{}) (*KsqlRestClient, error) {
+	httpEndpoint := d.Get(paramHttpEndpoint).(string)
+	apiKey, apiSecret, err := extractClusterApiKeyAndApiSecret(d)
+	if err != nil {
+		return nil, err
+	}
+	return meta.(*Client).ksqlRestClientFactory.CreateKsqlRestClient(httpEndpoint, apiKey, apiSecret), nil
+}
+
+func ksqlStatementCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := ksqlRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	statement := strings.TrimSpace(d.Get(paramStatement).(string))
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+	properties := convertToStringStringMap(d.Get(paramProperties).(map[string]interface{}))
+
+	results, resp, err := c.executeStatements(ctx, statement, properties)
+	if err != nil {
+		log.Printf("[ERROR] ksqlDB statement failed, %v, %s", resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+
+	for _, result := range results {
+		sourceType, sourceName, ok := result.sourceTypeAndName()
+		if !ok {
+			continue
+		}
+		d.SetId(sourceName)
+		if err := d.Set(paramSourceType, sourceType); err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		if err := d.Set(paramQueryId, result.CommandStatus.QueryId); err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		log.Printf("[DEBUG] Created ksqlDB %s %s", sourceType, sourceName)
+		return ksqlStatementRead(ctx, d, meta)
+	}
+
+	return diag.Errorf("error running ksqlDB statement: ksqlDB didn't report a created stream or table")
+}
+
+func ksqlStatementRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ksqlDB statement read for %s", d.Id())
+
+	c, err := ksqlRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	source, resp, err := c.describeSource(ctx, d.Id())
+	if err != nil {
+		log.Printf("[WARN] ksqlDB source describe failed for %s, %v, %s", d.Id(), resp, err)
+
+		if isKsqlSourceNotFoundError(err) && !d.IsNewResource() {
+			log.Printf("[WARN] ksqlDB source %s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
+
+		return createDiagnosticsWithDetails(err)
+	}
+
+	if err := d.Set(paramSourceName, source.Name); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramSourceType, strings.ToUpper(source.Type)); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	queryId := ""
+	if len(source.WriteQueries) > 0 {
+		queryId = source.WriteQueries[0].Id
+	}
+	if err := d.Set(paramQueryId, queryId); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	return nil
+}
+
+func ksqlStatementUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only credentials and delete_topic can be updated, and neither of them requires a request to ksqlDB
+	return ksqlStatementRead(ctx, d, meta)
+}
+
+func ksqlStatementDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] ksqlDB statement delete for %s", d.Id())
+
+	c, err := ksqlRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	source, _, err := c.describeSource(ctx, d.Id())
+	if isKsqlSourceNotFoundError(err) {
+		log.Printf("[INFO] ksqlDB source %s is already dropped", d.Id())
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error deleting ksqlDB source (%s), err: %s", d.Id(), err)
+	}
+
+	// A stream or table can't be dropped while persistent queries write to it
+	for _, query := range source.WriteQueries {
+		if _, _, err := c.executeStatements(ctx, fmt.Sprintf("TERMINATE %s;", query.Id), nil); err != nil {
+			return diag.Errorf("error terminating ksqlDB query %s of %s, err: %s", query.Id, d.Id(), err)
+		}
+		log.Printf("[DEBUG] Terminated ksqlDB query %s", query.Id)
+	}
+
+	dropStatement := fmt.Sprintf("DROP %s %s", strings.ToUpper(source.Type), quoteKsqlIdentifier(d.Id()))
+	if d.Get(paramDeleteTopic).(bool) {
+		dropStatement += " DELETE TOPIC"
+	}
+	if _, _, err := c.executeStatements(ctx, dropStatement+";", nil); err != nil && !isKsqlSourceNotFoundError(err) {
+		return diag.Errorf("error deleting ksqlDB source (%s), err: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] ksqlDB source %s was deleted successfully", d.Id())
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKsqlServer fakes a ksqlDB cluster with a single PAGEVIEWS_FEMALE stream that's created, described and dropped by the resource.
+func newTestKsqlServer(t *testing.T, statements *[]string) *httptest.Server {
+	exists := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ksql", r.URL.Path)
+		var request ksqlRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*statements = append(*statements, request.Ksql)
+		w.Header().Set("Content-Type", "application/json")
+		switch request.Ksql {
+		case "CREATE STREAM PAGEVIEWS_FEMALE AS SELECT * FROM PAGEVIEWS WHERE GENDER = 'FEMALE';":
+			exists = true
+			_, _ = w.Write([]byte(`[{"@type": "currentStatus", "commandId": "stream/` + "`PAGEVIEWS_FEMALE`" + `/create", "commandStatus": {"status": "SUCCESS", "queryId": "CSAS_PAGEVIEWS_FEMALE_1"}}]`))
+		case "DESCRIBE `PAGEVIEWS_FEMALE`;":
+			if !exists {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"@type": "statement_error", "error_code": 40001, "message": "Could not find STREAM/TABLE 'PAGEVIEWS_FEMALE' in the Metastore"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"@type": "sourceDescription", "sourceDescription": {"name": "PAGEVIEWS_FEMALE", "type": "STREAM", "topic": "PAGEVIEWS_FEMALE", "writeQueries": [{"id": "CSAS_PAGEVIEWS_FEMALE_1"}]}}]`))
+		case "TERMINATE CSAS_PAGEVIEWS_FEMALE_1;":
+			_, _ = w.Write([]byte(`[{"@type": "currentStatus", "commandStatus": {"status": "SUCCESS"}}]`))
+		case "DROP STREAM `PAGEVIEWS_FEMALE` DELETE TOPIC;":
+			exists = false
+			_, _ = w.Write([]byte(`[{"@type": "currentStatus", "commandStatus": {"status": "SUCCESS"}}]`))
+		default:
+			t.Fatalf("unexpected statement %s", request.Ksql)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestKsqlStatementCreateReadDelete(t *testing.T) {
+	var statements []string
+	server := newTestKsqlServer(t, &statements)
+	meta := &Client{ksqlRestClientFactory: &KsqlRestClientFactory{userAgent: "test-user-agent"}}
+	d := schema.TestResourceDataRaw(t, resourceKsqlStatement().Schema, map[string]interface{}{
+		paramHttpEndpoint: server.URL,
+		paramCredentials:  []interface{}{map[string]interface{}{paramKey: "foo", paramSecret: "bar"}},
+		paramStatement:    "CREATE STREAM PAGEVIEWS_FEMALE AS SELECT * FROM PAGEVIEWS WHERE GENDER = 'FEMALE'",
+		paramDeleteTopic:  true,
+	})
+
+	require.Empty(t, ksqlStatementCreate(context.Background(), d, meta))
+	require.Equal(t, "PAGEVIEWS_FEMALE", d.Id())
+	require.Equal(t, "PAGEVIEWS_FEMALE", d.Get(paramSourceName))
+	require.Equal(t, "STREAM", d.Get(paramSourceType))
+	require.Equal(t, "CSAS_PAGEVIEWS_FEMALE_1", d.Get(paramQueryId))
+
+	require.Empty(t, ksqlStatementDelete(context.Background(), d, meta))
+	require.Equal(t, []string{
+		"CREATE STREAM PAGEVIEWS_FEMALE AS SELECT * FROM PAGEVIEWS WHERE GENDER = 'FEMALE';",
+		"DESCRIBE `PAGEVIEWS_FEMALE`;",
+		"DESCRIBE `PAGEVIEWS_FEMALE`;",
+		"TERMINATE CSAS_PAGEVIEWS_FEMALE_1;",
+		"DROP STREAM `PAGEVIEWS_FEMALE` DELETE TOPIC;",
+	}, statements)
+
+	// A stream that was dropped outside of Terraform is removed from the state
+	require.Empty(t, ksqlStatementRead(context.Background(), d, meta))
+	require.Empty(t, d.Id())
+}
@@ -1,49 +1,100 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	defaultKsqlDbClusterCsu = 4
+
+	ksqlDbClusterStatusProvisioned = "PROVISIONED"
+
+	defaultKsqlDbClusterCreateTimeout = 1 * time.Hour
+	defaultKsqlDbClusterDeleteTimeout = 1 * time.Hour
+)
+
+// The ksqlDB API doesn't support resizing clusters, so a change of CSUs recreates the cluster
+var acceptedKsqlDbClusterCsus = []int{1, 2, 4, 8, 12}
+
 func resourceKsqlDbCluster() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKsqlDbClusterCreate,
 		ReadContext:   resourceKsqlDbClusterRead,
-		UpdateContext: resourceKsqlDbClusterUpdate,
 		DeleteContext: resourceKsqlDbClusterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: ksqlDbClusterImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultKsqlDbClusterCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultKsqlDbClusterDeleteTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the ksqlDB cluster.",
 			},
 			"topic_prefix": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"kafka_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Kafka cluster the ksqlDB cluster runs against.",
 			},
 			"kafka_api_key": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				Description:      "The Kafka API Key the ksqlDB cluster uses to access the Kafka cluster.",
+				ExactlyOneOf:     []string{"kafka_api_key", "credential_identity"},
+				RequiredWith:     []string{"kafka_api_key", "kafka_api_secret"},
+				DiffSuppressFunc: suppressImportedKsqlDbClusterCredentialsDiff,
 			},
 			"kafka_api_secret": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				Description:      "The Kafka API Secret the ksqlDB cluster uses to access the Kafka cluster.",
+				RequiredWith:     []string{"kafka_api_key", "kafka_api_secret"},
+				DiffSuppressFunc: suppressImportedKsqlDbClusterCredentialsDiff,
+			},
+			"credential_identity": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "The ID of the service account (e.g., `sa-abc123`) the ksqlDB cluster uses to access the Kafka cluster, instead of a Kafka API key.",
+				ExactlyOneOf:     []string{"kafka_api_key", "credential_identity"},
+				ValidateFunc:     validation.StringMatch(regexp.MustCompile("^sa-"), "the credential identity must be a service account ID of the form 'sa-'"),
+				DiffSuppressFunc: suppressImportedKsqlDbClusterCredentialsDiff,
+			},
+			"csu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultKsqlDbClusterCsu,
+				Description:  "The number of Confluent Streaming Units (CSUs) of the ksqlDB cluster. Accepted values are: `1`, `2`, `4`, `8` and `12`.",
+				ValidateFunc: validation.IntInSlice(acceptedKsqlDbClusterCsus),
 			},
 			"endpoint": &schema.Schema{
 				Type:     schema.TypeString,
@@ -52,6 +103,12 @@ func resourceKsqlDbCluster() *schema.Resource {
 			"environment_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the ksqlDB cluster, for example, `PROVISIONED`.",
 			},
 		},
 	}
@@ -81,195 +138,177 @@ type ksqlDbCluster struct {
 	OutputToicPrefix       string                 `json:"output_topic_prefix"`
 	PhysicalClusterId      string                 `json:"physical_cluster_id"`
 	Servers                int                    `json:"servers"`
-	ServiceAccountId       int                    `jsonb:"service_account_id"`
+	ServiceAccountId       int                    `json:"service_account_id"`
 	Status                 string                 `json:"status"`
 	Storage                int                    `json:"storage"`
 	TotalNumCsu            int                    `json:"total_num_csu"`
 }
 
-func resourceKsqlDbClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+type ksqlDbClusterResponse struct {
+	Cluster ksqlDbCluster
+	Error   string
+}
 
+func resourceKsqlDbClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	type simpleApiKey struct {
 		Key    string `json:"key"`
 		Secret string `json:"secret"`
 	}
 
 	type requestConfig struct {
-		AccountId      string       `json:"accountId"`
-		KafkaApiKey    simpleApiKey `json:"kafkaApiKey"`
-		KafkaClusterId string       `json:"kafkaClusterId"`
-		Name           string       `json:"name"`
-		TotalNumCsu    int          `json:"totalNumCsu"`
+		AccountId        string        `json:"accountId"`
+		KafkaApiKey      *simpleApiKey `json:"kafkaApiKey,omitempty"`
+		ServiceAccountId int           `json:"serviceAccountId,omitempty"`
+		KafkaClusterId   string        `json:"kafkaClusterId"`
+		Name             string        `json:"name"`
+		TotalNumCsu      int           `json:"totalNumCsu"`
 	}
 
 	type request struct {
 		Config requestConfig `json:"config"`
 	}
 
-	type response struct {
-		Cluster          ksqlDbCluster
-		Credentials      string
-		Error            string
-		ValidationErrors string
-	}
-
 	c := m.(*Client)
 
-	environment_id := d.Get("environment_id").(string)
-
-	client := &http.Client{Timeout: 10 * time.Second}
+	environmentId := d.Get("environment_id").(string)
 
-	url := fmt.Sprintf("%s/ksqls?account_id=%s", "https://api.confluent.cloud", environment_id)
-
-	post_data := request{
+	createRequest := request{
 		requestConfig{
-			AccountId:      environment_id,
-			KafkaApiKey:    simpleApiKey{Key: d.Get("kafka_api_key").(string), Secret: d.Get("kafka_api_secret").(string)},
+			AccountId:      environmentId,
 			KafkaClusterId: d.Get("kafka_id").(string),
 			Name:           d.Get("name").(string),
-			TotalNumCsu:    4,
+			TotalNumCsu:    d.Get("csu").(int),
 		},
 	}
 
-	json_post_data, err := json.Marshal(post_data)
-	requestBody := bytes.NewBuffer(json_post_data)
+	if credentialIdentity := d.Get("credential_identity").(string); credentialIdentity != "" {
+		serviceAccountIntegerId, err := saResourceIdToSaIntegerId(c, credentialIdentity)
+		if err != nil {
+			return diag.Errorf("error resolving credential identity %s: %s", credentialIdentity, err)
+		}
+		createRequest.Config.ServiceAccountId = serviceAccountIntegerId
+	} else {
+		createRequest.Config.KafkaApiKey = &simpleApiKey{Key: d.Get("kafka_api_key").(string), Secret: d.Get("kafka_api_secret").(string)}
+	}
 
-	log.Printf("key: %s secret %s", d.Get("kafka_api_key").(string), d.Get("kafka_api_secret").(string))
-	log.Printf("req-body: %v", requestBody)
+	// The request isn't logged since it may contain the Kafka API secret
+	log.Printf("[DEBUG] Creating ksqlDB cluster %s in environment %s", createRequest.Config.Name, environmentId)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, requestBody)
+	var resp ksqlDbClusterResponse
+	_, err := c.legacyClient.Post(ctx, "/ksqls", url.Values{"account_id": {environmentId}}, createRequest, &resp)
 	if err != nil {
+		log.Printf("[ERROR] ksqlDB cluster create failed for environment %s, %s", environmentId, err)
 		return diag.FromErr(err)
 	}
-	req.Header.Add("Content-type", "application/json")
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
-
-	r, err := client.Do(req)
-	if err != nil {
-		return diag.FromErr(err)
+	if resp.Error != "" {
+		return diag.Errorf("unexpected API response: %s", resp.Error)
 	}
-	defer r.Body.Close()
 
-	if r.StatusCode != 200 {
-		body, _ := ioutil.ReadAll(r.Body)
-		log.Printf("body: %v", string(body))
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
+	d.SetId(resp.Cluster.Id)
+	log.Printf("[DEBUG] Created ksqlDB cluster %s", d.Id())
+
+	if err := waitForKsqlDbClusterToProvision(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for ksqlDB cluster (%s) to provision: %s", d.Id(), err)
 	}
-	var resp response
 
-	err = json.NewDecoder(r.Body).Decode(&resp)
+	return resourceKsqlDbClusterRead(ctx, d, m)
+}
+
+func executeKsqlDbClusterRead(ctx context.Context, c *Client, environmentId, clusterId string) (ksqlDbCluster, *http.Response, error) {
+	var resp ksqlDbClusterResponse
+	r, err := c.legacyClient.Get(ctx, fmt.Sprintf("/ksqls/%s", clusterId), url.Values{"account_id": {environmentId}}, &resp)
 	if err != nil {
-		return diag.FromErr(err)
+		return ksqlDbCluster{}, r, err
 	}
-
 	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+		return ksqlDbCluster{}, r, fmt.Errorf("unexpected API response: %s", resp.Error)
 	}
-
-	d.Set("endpoint", resp.Cluster.Endpoint)
-	d.Set("topic_prefix", resp.Cluster.OutputToicPrefix)
-	d.Set("name", resp.Cluster.Name)
-	d.SetId(resp.Cluster.Id)
-
-	return diags
+	return resp.Cluster, r, nil
 }
 
 func resourceKsqlDbClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	type response struct {
-		Cluster ksqlDbCluster
-		Error   string
-	}
-
+	log.Printf("[INFO] ksqlDB cluster read for %s", d.Id())
 	c := m.(*Client)
 
-	ksqlDbId := d.Id()
-	environment_id := d.Get("environment_id")
-
-	client := &http.Client{Timeout: 10 * time.Second}
+	environmentId := d.Get("environment_id").(string)
 
-	url := fmt.Sprintf("%s/ksqls/%s?account_id=%s", "https://api.confluent.cloud", ksqlDbId, environment_id)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	cluster, resp, err := executeKsqlDbClusterRead(ctx, c, environmentId, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
-	}
+		log.Printf("[WARN] ksqlDB cluster get failed for id %s, %v, %s", d.Id(), resp, err)
 
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
-	r, err := client.Do(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer r.Body.Close()
-
-	if r.StatusCode != 200 {
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
-	}
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] ksqlDB cluster with id=%s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
 
-	var resp response
-
-	err = json.NewDecoder(r.Body).Decode(&resp)
-	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+	d.Set("endpoint", cluster.Endpoint)
+	d.Set("topic_prefix", cluster.OutputToicPrefix)
+	d.Set("name", cluster.Name)
+	d.Set("status", cluster.Status)
+	if cluster.KafkaClusterId != "" {
+		d.Set("kafka_id", cluster.KafkaClusterId)
+	}
+	if cluster.TotalNumCsu != 0 {
+		d.Set("csu", cluster.TotalNumCsu)
 	}
 
-	d.Set("endpoint", resp.Cluster.Endpoint)
-	d.Set("topic_prefix", resp.Cluster.OutputToicPrefix)
-	d.Set("name", resp.Cluster.Name)
-	d.SetId(resp.Cluster.Id)
-
-	return diags
-}
-
-func resourceKsqlDbClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	return nil
 }
 
 func resourceKsqlDbClusterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
+	log.Printf("[INFO] ksqlDB cluster delete for %s", d.Id())
 	c := m.(*Client)
 
-	ksqlDbId := d.Id()
-	environment_id := d.Get("environment_id")
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	url := fmt.Sprintf("%s/ksqls/%s?account_id=%s", "https://api.confluent.cloud", ksqlDbId, environment_id)
+	environmentId := d.Get("environment_id").(string)
 
-	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
-	if err != nil {
-		return diag.FromErr(err)
+	var resp ksqlDbClusterResponse
+	r, err := c.legacyClient.Delete(ctx, fmt.Sprintf("/ksqls/%s", d.Id()), url.Values{"account_id": {environmentId}}, nil, &resp)
+	if HasStatusNotFound(r) {
+		log.Printf("[INFO] ksqlDB cluster %s is already deleted", d.Id())
+		return nil
 	}
-
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
-	r, err := client.Do(req)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	defer r.Body.Close()
+	if resp.Error != "" {
+		return diag.Errorf("unexpected API response: %s", resp.Error)
+	}
 
-	if r.StatusCode != 200 {
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
+	if err := waitForKsqlDbClusterToBeDeleted(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for ksqlDB cluster (%s) to be deleted: %s", d.Id(), err)
 	}
 
-	var resp response
+	log.Printf("[INFO] ksqlDB cluster %s was deleted successfully", d.Id())
 
-	err = json.NewDecoder(r.Body).Decode(&resp)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	return nil
+}
 
-	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+// suppressImportedKsqlDbClusterCredentialsDiff keeps an imported ksqlDB cluster from being recreated,
+// since the ksqlDB API never returns the Kafka API key or the credential identity the cluster was created with.
+func suppressImportedKsqlDbClusterCredentialsDiff(_, old, _ string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
+func ksqlDbClusterImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	envIDAndClusterID := d.Id()
+	parts := strings.Split(envIDAndClusterID, "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format for ksqlDB cluster import: expected '<env ID>/<lksqlc ID>'")
 	}
 
-	return diags
+	environmentId := parts[0]
+	clusterId := parts[1]
+	d.SetId(clusterId)
+	d.Set("environment_id", environmentId)
+	log.Printf("[INFO] ksqlDB cluster import for %s", clusterId)
+
+	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,104 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKsqlDbClusterProvisionStatus(t *testing.T) {
+	status := "PROVISIONING"
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ksqls/lksqlc-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"cluster": {"id": "lksqlc-abc123", "status": "` + status + `"}}`))
+	})
+	refresh := ksqlDbClusterProvisionStatus(context.Background(), c, "env-abc123", "lksqlc-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	status = ksqlDbClusterStatusProvisioned
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+
+	status = stateFailed
+	_, state, err = refresh()
+	require.Error(t, err)
+	require.Equal(t, stateFailed, state)
+}
+
+func TestKsqlDbClusterDeleteStatus(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, state, err := ksqlDbClusterDeleteStatus(context.Background(), c, "env-abc123", "lksqlc-abc123")()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+}
+
+func TestImportedKsqlDbClusterIsNotRecreated(t *testing.T) {
+	// The state of an imported ksqlDB cluster has no credentials
+	importedState := &terraform.InstanceState{
+		ID: "lksqlc-abc123",
+		Attributes: map[string]string{
+			"id":             "lksqlc-abc123",
+			"name":           "orders",
+			"kafka_id":       "lkc-abc123",
+			"environment_id": "env-abc123",
+			"csu":            "4",
+		},
+	}
+	configs := map[string]map[string]interface{}{
+		"kafka_api_key":       {"kafka_api_key": "ABCDEFGH", "kafka_api_secret": "secret"},
+		"credential_identity": {"credential_identity": "sa-abc123"},
+	}
+	for name, credentials := range configs {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{"name": "orders", "kafka_id": "lkc-abc123", "environment_id": "env-abc123"}
+			for attribute, value := range credentials {
+				config[attribute] = value
+			}
+			diff, err := resourceKsqlDbCluster().Diff(context.Background(), importedState, terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+			require.False(t, diff != nil && diff.RequiresNew(), "unexpected diff %v", diff)
+		})
+	}
+}
+
+func TestExecuteKsqlDbClusterLookupByName(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ksqls", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"clusters": [{"id": "lksqlc-abc123", "name": "orders", "total_num_csu": 4}, {"id": "lksqlc-def456", "name": "payments"}]}`))
+	})
+
+	cluster, err := executeKsqlDbClusterLookupByName(context.Background(), c, "env-abc123", "orders")
+	require.NoError(t, err)
+	require.Equal(t, "lksqlc-abc123", cluster.Id)
+	require.Equal(t, 4, cluster.TotalNumCsu)
+
+	_, err = executeKsqlDbClusterLookupByName(context.Background(), c, "env-abc123", "shipments")
+	require.EqualError(t, err, `no ksqlDB cluster named "shipments" in env-abc123`)
+}
@@ -0,0 +1,396 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramConnectionTypes = "connection_types"
+	paramCidr            = "cidr"
+	paramZones           = "zones"
+	paramDnsDomain       = "dns_domain"
+	paramZonalSubdomains = "zonal_subdomains"
+
+	connectionTypePeering        = "PEERING"
+	connectionTypePrivateLink    = "PRIVATELINK"
+	connectionTypeTransitGateway = "TRANSITGATEWAY"
+
+	networkingStatusReady = "READY"
+
+	networksPath = "/networking/v1/networks"
+
+	defaultNetworkCreateTimeout = 2 * time.Hour
+	defaultNetworkDeleteTimeout = 2 * time.Hour
+)
+
+var acceptedConnectionTypes = []string{connectionTypePeering, connectionTypePrivateLink, connectionTypeTransitGateway}
+
+// The networking/v1 API isn't covered by ccloud-sdk-go-v2 yet, so its objects are modeled here
+// and sent via the legacy client.
+type networkingV1ObjectReference struct {
+	Id string `json:"id"`
+}
+
+type networkingV1NetworkSpec struct {
+	DisplayName     string                       `json:"display_name,omitempty"`
+	Cloud           string                       `json:"cloud,omitempty"`
+	Region          string                       `json:"region,omitempty"`
+	ConnectionTypes []string                     `json:"connection_types,omitempty"`
+	Cidr            string                       `json:"cidr,omitempty"`
+	Zones           []string                     `json:"zones,omitempty"`
+	DnsDomain       string                       `json:"dns_domain,omitempty"`
+	ZonalSubdomains map[string]string            `json:"zonal_subdomains,omitempty"`
+	Environment     *networkingV1ObjectReference `json:"environment,omitempty"`
+}
+
+type networkingV1Status struct {
+	Phase        string `json:"phase"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+type networkingV1Network struct {
+	ApiVersion string                  `json:"api_version,omitempty"`
+	Kind       string                  `json:"kind,omitempty"`
+	Id         string                  `json:"id,omitempty"`
+	Spec       networkingV1NetworkSpec `json:"spec"`
+	Status     networkingV1Status      `json:"status"`
+}
+
+// networkingV1Request is the body of create and update requests, which only carry the spec of the object.
+type networkingV1Request struct {
+	Spec interface{} `json:"spec"`
+}
+
+// networkingV1DisplayNameSpec is the spec of update requests, since display_name is the only argument
+// of networking objects that can be updated in place.
+type networkingV1DisplayNameSpec struct {
+	DisplayName string                       `json:"display_name"`
+	Environment *networkingV1ObjectReference `json:"environment"`
+}
+
+type networkingV1NetworkList struct {
+	Data []networkingV1Network `json:"data"`
+}
+
+func executeNetworkingDisplayNameUpdate(ctx context.Context, c *Client, path, environmentId, displayName string) (*http.Response, error) {
+	updateSpec := networkingV1DisplayNameSpec{
+		DisplayName: displayName,
+		Environment: &networkingV1ObjectReference{Id: environmentId},
+	}
+	return c.legacyClient.Patch(ctx, path, nil, networkingV1Request{Spec: updateSpec}, nil)
+}
+
+// failureMessage describes why the networking object ended up in the FAILED phase.
+func (s networkingV1Status) failureMessage() string {
+	if s.ErrorMessage == "" {
+		return "no details were provided"
+	}
+	if s.ErrorCode == "" {
+		return s.ErrorMessage
+	}
+	return fmt.Sprintf("%s (%s)", s.ErrorMessage, s.ErrorCode)
+}
+
+func networkResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: networkCreate,
+		ReadContext:   networkRead,
+		UpdateContext: networkUpdate,
+		DeleteContext: networkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: networkImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultNetworkCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultNetworkDeleteTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			paramDisplayName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The name of the Network.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramCloud: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The cloud service provider in which the Network exists.",
+				ValidateFunc: validation.StringInSlice(acceptedCloudProviders, false),
+			},
+			paramRegion: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The cloud service provider region where the Network exists.",
+			},
+			paramConnectionTypes: {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(acceptedConnectionTypes, false),
+				},
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The connection types the Network supports.",
+			},
+			paramCidr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The IPv4 CIDR block of the Network, for example, `10.1.0.0/16`.",
+				ValidateFunc: validation.IsCIDR,
+			},
+			paramZones: {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The 3 availability zones of the Network.",
+			},
+			paramDnsDomain: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The root DNS domain of the Network.",
+			},
+			paramZonalSubdomains: {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The DNS subdomains of the Network by availability zone.",
+			},
+			paramEnvironment: environmentSchema(),
+		},
+	}
+}
+
+// networkReferenceSchema is the network block of the objects that belong to a Network, e.g., peerings.
+func networkReferenceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramId: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ID of the Network, for example, `n-abc123`.",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^n-"), "the network ID must be of the form 'n-'"),
+				},
+			},
+		},
+		Required:    true,
+		MaxItems:    1,
+		ForceNew:    true,
+		Description: "The Network the object belongs to.",
+	}
+}
+
+func networkPath(networkId string) string {
+	return fmt.Sprintf("%s/%s", networksPath, networkId)
+}
+
+func networkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	spec := networkingV1NetworkSpec{
+		DisplayName:     d.Get(paramDisplayName).(string),
+		Cloud:           d.Get(paramCloud).(string),
+		Region:          d.Get(paramRegion).(string),
+		ConnectionTypes: convertToStringSlice(d.Get(paramConnectionTypes).([]interface{})),
+		Cidr:            d.Get(paramCidr).(string),
+		Zones:           convertToStringSlice(d.Get(paramZones).([]interface{})),
+		Environment:     &networkingV1ObjectReference{Id: environmentId},
+	}
+	log.Printf("[DEBUG] Creating Network with spec %+v", spec)
+
+	var network networkingV1Network
+	resp, err := c.legacyClient.Post(ctx, networksPath, nil, networkingV1Request{Spec: spec}, &network)
+	if err != nil {
+		log.Printf("[ERROR] Network create failed %v, %s", resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+	d.SetId(network.Id)
+
+	log.Printf("[DEBUG] Created Network %s", d.Id())
+
+	if err := waitForNetworkToProvision(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for Network (%s) to provision: %s", d.Id(), err)
+	}
+
+	return networkRead(ctx, d, meta)
+}
+
+func executeNetworkRead(ctx context.Context, c *Client, environmentId, networkId string) (networkingV1Network, *http.Response, error) {
+	var network networkingV1Network
+	resp, err := c.legacyClient.Get(ctx, networkPath(networkId), url.Values{"environment": {environmentId}}, &network)
+	return network, resp, err
+}
+
+func networkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Network read for %s", d.Id())
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	_, err = readNetworkAndSetAttributes(ctx, d, meta, environmentId, d.Id())
+
+	return createDiagnosticsWithDetails(err)
+}
+
+func readNetworkAndSetAttributes(ctx context.Context, d *schema.ResourceData, meta interface{}, environmentId, networkId string) ([]*schema.ResourceData, error) {
+	c := meta.(*Client)
+
+	network, resp, err := executeNetworkRead(ctx, c, environmentId, networkId)
+	if err != nil {
+		log.Printf("[WARN] Network get failed for id %s, %v, %s", networkId, resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Network with id=%s is not found", networkId)
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if err := setNetworkAttributes(d, network); err != nil {
+		return nil, err
+	}
+	if err := setEnvironmentId(environmentId, d); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setNetworkAttributes(d *schema.ResourceData, network networkingV1Network) error {
+	if err := d.Set(paramDisplayName, network.Spec.DisplayName); err != nil {
+		return err
+	}
+	if err := d.Set(paramCloud, network.Spec.Cloud); err != nil {
+		return err
+	}
+	if err := d.Set(paramRegion, network.Spec.Region); err != nil {
+		return err
+	}
+	if err := d.Set(paramConnectionTypes, network.Spec.ConnectionTypes); err != nil {
+		return err
+	}
+	if err := d.Set(paramCidr, network.Spec.Cidr); err != nil {
+		return err
+	}
+	if err := d.Set(paramZones, network.Spec.Zones); err != nil {
+		return err
+	}
+	if err := d.Set(paramDnsDomain, network.Spec.DnsDomain); err != nil {
+		return err
+	}
+	return d.Set(paramZonalSubdomains, network.Spec.ZonalSubdomains)
+}
+
+func networkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange(paramDisplayName) {
+		c := meta.(*Client)
+
+		environmentId, err := validEnvironmentId(d)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+
+		resp, err := executeNetworkingDisplayNameUpdate(ctx, c, networkPath(d.Id()), environmentId, d.Get(paramDisplayName).(string))
+		if err != nil {
+			log.Printf("[ERROR] Network update failed for id %s, %v, %s", d.Id(), resp, err)
+			return createDiagnosticsWithDetails(err)
+		}
+	}
+
+	return networkRead(ctx, d, meta)
+}
+
+func networkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Network delete for %s", d.Id())
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	resp, err := c.legacyClient.Delete(ctx, networkPath(d.Id()), url.Values{"environment": {environmentId}}, nil, nil)
+	if HasStatusNotFound(resp) {
+		log.Printf("[INFO] Network %s is already deleted", d.Id())
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error deleting Network (%s), err: %s", d.Id(), err)
+	}
+
+	if err := waitForNetworkToBeDeleted(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for Network (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Network %s was deleted successfully", d.Id())
+
+	return nil
+}
+
+func networkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	envIDAndNetworkID := d.Id()
+	parts := strings.Split(envIDAndNetworkID, "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format for network import: expected '<env ID>/<n ID>'")
+	}
+
+	environmentId := parts[0]
+	networkId := parts[1]
+	d.SetId(networkId)
+	log.Printf("[INFO] Network import for %s", networkId)
+
+	return readNetworkAndSetAttributes(ctx, d, meta, environmentId, networkId)
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvisionStatus(t *testing.T) {
+	phase := "PROVISIONING"
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/networks/n-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "n-abc123", "spec": {"dns_domain": "abc123.us-east-2.aws.confluent.cloud"}, "status": {"phase": "` + phase + `", "error_code": "QUOTA_EXCEEDED", "error_message": "too many networks"}}`))
+	})
+	refresh := networkProvisionStatus(context.Background(), c, "env-abc123", "n-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	phase = networkingStatusReady
+	network, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+	require.Equal(t, "abc123.us-east-2.aws.confluent.cloud", network.(networkingV1Network).Spec.DnsDomain)
+
+	phase = stateFailed
+	_, state, err = refresh()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too many networks (QUOTA_EXCEEDED)")
+	require.Equal(t, stateFailed, state)
+}
+
+func TestNetworkDeleteStatus(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, state, err := networkDeleteStatus(context.Background(), c, "env-abc123", "n-abc123")()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+}
+
+func TestExecuteNetworkingDisplayNameUpdate(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "/networking/v1/networks/n-abc123", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"spec": {"display_name": "prod", "environment": {"id": "env-abc123"}}}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "n-abc123"}`))
+	})
+
+	_, err := executeNetworkingDisplayNameUpdate(context.Background(), c, networkPath("n-abc123"), "env-abc123", "prod")
+	require.NoError(t, err)
+}
+
+func TestExecuteNetworkLookupByDisplayName(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/networks", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "n-abc123", "spec": {"display_name": "prod", "connection_types": ["PEERING"], "zonal_subdomains": {"use2-az1": "use2-az1.abc123.us-east-2.aws.confluent.cloud"}}},
+			{"id": "n-def456", "spec": {"display_name": "dev"}},
+			{"id": "n-ghi789", "spec": {"display_name": "dev"}}
+		]}`))
+	})
+
+	network, err := executeNetworkLookupByDisplayName(context.Background(), c, "env-abc123", "prod")
+	require.NoError(t, err)
+	require.Equal(t, "n-abc123", network.Id)
+	require.Equal(t, []string{connectionTypePeering}, network.Spec.ConnectionTypes)
+	require.Equal(t, "use2-az1.abc123.us-east-2.aws.confluent.cloud", network.Spec.ZonalSubdomains["use2-az1"])
+
+	_, err = executeNetworkLookupByDisplayName(context.Background(), c, "env-abc123", "dev")
+	require.EqualError(t, err, "there are multiple Networks with display_name=dev in env-abc123")
+
+	_, err = executeNetworkLookupByDisplayName(context.Background(), c, "env-abc123", "staging")
+	require.EqualError(t, err, "the Network with display_name=staging was not found in env-abc123")
+}
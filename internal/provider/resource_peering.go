@@ -0,0 +1,493 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramAws                = "aws"
+	paramAzure              = "azure"
+	paramGcp                = "gcp"
+	paramAccount            = "account"
+	paramVpc                = "vpc"
+	paramRoutes             = "routes"
+	paramCustomerRegion     = "customer_region"
+	paramTenant             = "tenant"
+	paramVnet               = "vnet"
+	paramProject            = "project"
+	paramVpcNetwork         = "vpc_network"
+	paramImportCustomRoutes = "import_custom_routes"
+
+	peeringKindAws   = "AwsPeering"
+	peeringKindAzure = "AzurePeering"
+	peeringKindGcp   = "GcpPeering"
+
+	networkingStatusPendingAccept = "PENDING_ACCEPT"
+
+	peeringsPath = "/networking/v1/peerings"
+
+	defaultPeeringCreateTimeout = 1 * time.Hour
+	defaultPeeringDeleteTimeout = 1 * time.Hour
+)
+
+var acceptedCloudBlocks = []string{paramAws, paramAzure, paramGcp}
+
+var awsAccountIdRegex = regexp.MustCompile(`^\d{12}$`)
+
+// networkingV1PeeringCloud holds the fields of all 3 kinds of peerings, only the ones of Kind are set.
+type networkingV1PeeringCloud struct {
+	Kind string `json:"kind"`
+	// AwsPeering
+	Account string   `json:"account,omitempty"`
+	Vpc     string   `json:"vpc,omitempty"`
+	Routes  []string `json:"routes,omitempty"`
+	// AzurePeering
+	Tenant string `json:"tenant,omitempty"`
+	Vnet   string `json:"vnet,omitempty"`
+	// AwsPeering and AzurePeering
+	CustomerRegion string `json:"customer_region,omitempty"`
+	// GcpPeering
+	Project            string `json:"project,omitempty"`
+	VpcNetwork         string `json:"vpc_network,omitempty"`
+	ImportCustomRoutes bool   `json:"import_custom_routes,omitempty"`
+}
+
+type networkingV1PeeringSpec struct {
+	DisplayName string                       `json:"display_name,omitempty"`
+	Cloud       *networkingV1PeeringCloud    `json:"cloud,omitempty"`
+	Environment *networkingV1ObjectReference `json:"environment,omitempty"`
+	Network     *networkingV1ObjectReference `json:"network,omitempty"`
+}
+
+type networkingV1Peering struct {
+	Id     string                  `json:"id,omitempty"`
+	Spec   networkingV1PeeringSpec `json:"spec"`
+	Status networkingV1Status      `json:"status"`
+}
+
+func peeringResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: peeringCreate,
+		ReadContext:   peeringRead,
+		UpdateContext: peeringUpdate,
+		DeleteContext: peeringDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: peeringImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultPeeringCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultPeeringDeleteTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			paramDisplayName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The name of the Peering.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramAws:         awsPeeringSchema(),
+			paramAzure:       azurePeeringSchema(),
+			paramGcp:         gcpPeeringSchema(),
+			paramNetwork:     networkReferenceSchema(),
+			paramEnvironment: environmentSchema(),
+		},
+	}
+}
+
+func awsPeeringSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramAccount: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The AWS account ID of the peer VPC owner.",
+					ValidateFunc: validation.StringMatch(awsAccountIdRegex, "the AWS account ID must consist of 12 digits"),
+				},
+				paramVpc: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ID of the AWS VPC to peer with.",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^vpc-"), "the VPC ID must be of the form 'vpc-'"),
+				},
+				paramRoutes: {
+					Type: schema.TypeList,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+					Required:    true,
+					ForceNew:    true,
+					MinItems:    1,
+					Description: "The CIDR blocks of the AWS VPC that Confluent Cloud routes traffic to.",
+				},
+				paramCustomerRegion: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The region of the AWS VPC.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+		ExactlyOneOf: acceptedCloudBlocks,
+	}
+}
+
+func azurePeeringSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramTenant: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ID of the Azure tenant of the peer VNet.",
+					ValidateFunc: validation.IsUUID,
+				},
+				paramVnet: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The resource ID of the Azure VNet to peer with.",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^/subscriptions/"), "the VNet must be an Azure resource ID of the form '/subscriptions/'"),
+				},
+				paramCustomerRegion: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The region of the Azure VNet.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+		ExactlyOneOf: acceptedCloudBlocks,
+	}
+}
+
+func gcpPeeringSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramProject: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ID of the GCP project of the peer VPC network.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				paramVpcNetwork: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The name of the GCP VPC network to peer with.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				paramImportCustomRoutes: {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					ForceNew:    true,
+					Default:     false,
+					Description: "Whether to import the custom routes of the GCP VPC network.",
+				},
+			},
+		},
+		ExactlyOneOf: acceptedCloudBlocks,
+	}
+}
+
+func peeringPath(peeringId string) string {
+	return fmt.Sprintf("%s/%s", peeringsPath, peeringId)
+}
+
+func extractPeeringCloud(d *schema.ResourceData) (*networkingV1PeeringCloud, error) {
+	if aws := d.Get(paramAws).([]interface{}); len(aws) == 1 && aws[0] != nil {
+		awsMap := aws[0].(map[string]interface{})
+		return &networkingV1PeeringCloud{
+			Kind:           peeringKindAws,
+			Account:        awsMap[paramAccount].(string),
+			Vpc:            awsMap[paramVpc].(string),
+			Routes:         convertToStringSlice(awsMap[paramRoutes].([]interface{})),
+			CustomerRegion: awsMap[paramCustomerRegion].(string),
+		}, nil
+	}
+	if azure := d.Get(paramAzure).([]interface{}); len(azure) == 1 && azure[0] != nil {
+		azureMap := azure[0].(map[string]interface{})
+		return &networkingV1PeeringCloud{
+			Kind:           peeringKindAzure,
+			Tenant:         azureMap[paramTenant].(string),
+			Vnet:           azureMap[paramVnet].(string),
+			CustomerRegion: azureMap[paramCustomerRegion].(string),
+		}, nil
+	}
+	if gcp := d.Get(paramGcp).([]interface{}); len(gcp) == 1 && gcp[0] != nil {
+		gcpMap := gcp[0].(map[string]interface{})
+		return &networkingV1PeeringCloud{
+			Kind:               peeringKindGcp,
+			Project:            gcpMap[paramProject].(string),
+			VpcNetwork:         gcpMap[paramVpcNetwork].(string),
+			ImportCustomRoutes: gcpMap[paramImportCustomRoutes].(bool),
+		}, nil
+	}
+	return nil, fmt.Errorf("exactly one of %q, %q or %q blocks must be specified", paramAws, paramAzure, paramGcp)
+}
+
+func peeringCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	cloud, err := extractPeeringCloud(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	spec := networkingV1PeeringSpec{
+		DisplayName: d.Get(paramDisplayName).(string),
+		Cloud:       cloud,
+		Environment: &networkingV1ObjectReference{Id: environmentId},
+		Network:     &networkingV1ObjectReference{Id: extractNetworkId(d)},
+	}
+	log.Printf("[DEBUG] Creating Peering with spec %+v", spec)
+
+	var peering networkingV1Peering
+	resp, err := c.legacyClient.Post(ctx, peeringsPath, nil, networkingV1Request{Spec: spec}, &peering)
+	if err != nil {
+		log.Printf("[ERROR] Peering create failed %v, %s", resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+	d.SetId(peering.Id)
+
+	log.Printf("[DEBUG] Created Peering %s", d.Id())
+
+	phase, err := waitForPeeringToProvision(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error waiting for Peering (%s) to provision: %s", d.Id(), err)
+	}
+
+	diags := peeringRead(ctx, d, meta)
+	if phase == networkingStatusPendingAccept {
+		// The Peering exists but doesn't carry traffic until it's accepted on the cloud provider side,
+		// which usually happens later in the same configuration, so it's reported as a warning rather than an error.
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Peering (%s) is %s", d.Id(), networkingStatusPendingAccept),
+			Detail:   pendingAcceptPeeringDetail(cloud.Kind),
+		})
+	}
+	return diags
+}
+
+func pendingAcceptPeeringDetail(kind string) string {
+	switch kind {
+	case peeringKindAws:
+		return "Accept the VPC peering connection request from Confluent Cloud in your AWS account (e.g., with the aws_vpc_peering_connection_accepter resource)."
+	case peeringKindAzure:
+		return "Make sure the Confluent Cloud service principal has been granted access to your Azure VNet, the peering is retried once it has."
+	case peeringKindGcp:
+		return "Create the matching VPC network peering from your GCP VPC network to the Confluent Cloud VPC network (e.g., with the google_compute_network_peering resource)."
+	}
+	return "Accept the peering request in your cloud provider account."
+}
+
+func executePeeringRead(ctx context.Context, c *Client, environmentId, peeringId string) (networkingV1Peering, *http.Response, error) {
+	var peering networkingV1Peering
+	resp, err := c.legacyClient.Get(ctx, peeringPath(peeringId), url.Values{"environment": {environmentId}}, &peering)
+	return peering, resp, err
+}
+
+func peeringRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Peering read for %s", d.Id())
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	_, err = readPeeringAndSetAttributes(ctx, d, meta, environmentId, d.Id())
+
+	return createDiagnosticsWithDetails(err)
+}
+
+func readPeeringAndSetAttributes(ctx context.Context, d *schema.ResourceData, meta interface{}, environmentId, peeringId string) ([]*schema.ResourceData, error) {
+	c := meta.(*Client)
+
+	peering, resp, err := executePeeringRead(ctx, c, environmentId, peeringId)
+	if err != nil {
+		log.Printf("[WARN] Peering get failed for id %s, %v, %s", peeringId, resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Peering with id=%s is not found", peeringId)
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if err := d.Set(paramDisplayName, peering.Spec.DisplayName); err != nil {
+		return nil, err
+	}
+	if err := setPeeringCloud(d, peering.Spec.Cloud); err != nil {
+		return nil, err
+	}
+	if peering.Spec.Network != nil {
+		if err := setNetworkId(peering.Spec.Network.Id, d); err != nil {
+			return nil, err
+		}
+	}
+	if err := setEnvironmentId(environmentId, d); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setPeeringCloud(d *schema.ResourceData, cloud *networkingV1PeeringCloud) error {
+	if err := clearCloudBlocks(d); err != nil {
+		return err
+	}
+	if cloud == nil {
+		return nil
+	}
+
+	switch cloud.Kind {
+	case peeringKindAws:
+		return d.Set(paramAws, []interface{}{map[string]interface{}{
+			paramAccount:        cloud.Account,
+			paramVpc:            cloud.Vpc,
+			paramRoutes:         cloud.Routes,
+			paramCustomerRegion: cloud.CustomerRegion,
+		}})
+	case peeringKindAzure:
+		return d.Set(paramAzure, []interface{}{map[string]interface{}{
+			paramTenant:         cloud.Tenant,
+			paramVnet:           cloud.Vnet,
+			paramCustomerRegion: cloud.CustomerRegion,
+		}})
+	case peeringKindGcp:
+		return d.Set(paramGcp, []interface{}{map[string]interface{}{
+			paramProject:            cloud.Project,
+			paramVpcNetwork:         cloud.VpcNetwork,
+			paramImportCustomRoutes: cloud.ImportCustomRoutes,
+		}})
+	}
+	return fmt.Errorf("unknown Peering kind %q", cloud.Kind)
+}
+
+// clearCloudBlocks empties the aws, azure and gcp blocks, so that only the block of the current kind is set afterwards.
+func clearCloudBlocks(d *schema.ResourceData) error {
+	for _, cloudBlock := range acceptedCloudBlocks {
+		if err := d.Set(cloudBlock, []interface{}{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func peeringUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange(paramDisplayName) {
+		c := meta.(*Client)
+
+		environmentId, err := validEnvironmentId(d)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+
+		resp, err := executeNetworkingDisplayNameUpdate(ctx, c, peeringPath(d.Id()), environmentId, d.Get(paramDisplayName).(string))
+		if err != nil {
+			log.Printf("[ERROR] Peering update failed for id %s, %v, %s", d.Id(), resp, err)
+			return createDiagnosticsWithDetails(err)
+		}
+	}
+
+	return peeringRead(ctx, d, meta)
+}
+
+func peeringDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Peering delete for %s", d.Id())
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	resp, err := c.legacyClient.Delete(ctx, peeringPath(d.Id()), url.Values{"environment": {environmentId}}, nil, nil)
+	if HasStatusNotFound(resp) {
+		log.Printf("[INFO] Peering %s is already deleted", d.Id())
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error deleting Peering (%s), err: %s", d.Id(), err)
+	}
+
+	if err := waitForPeeringToBeDeleted(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for Peering (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Peering %s was deleted successfully", d.Id())
+
+	return nil
+}
+
+func peeringImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	envIDAndPeeringID := d.Id()
+	parts := strings.Split(envIDAndPeeringID, "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format for peering import: expected '<env ID>/<peer ID>'")
+	}
+
+	environmentId := parts[0]
+	peeringId := parts[1]
+	d.SetId(peeringId)
+	log.Printf("[INFO] Peering import for %s", peeringId)
+
+	return readPeeringAndSetAttributes(ctx, d, meta, environmentId, peeringId)
+}
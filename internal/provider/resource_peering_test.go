@@ -0,0 +1,235 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeeringProvisionStatus(t *testing.T) {
+	phase := "PROVISIONING"
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/peerings/peer-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "peer-abc123", "status": {"phase": "` + phase + `", "error_message": "the VPC CIDR overlaps with the Network CIDR"}}`))
+	})
+	refresh := peeringProvisionStatus(context.Background(), c, "env-abc123", "peer-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	phase = networkingStatusPendingAccept
+	peering, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+	require.Equal(t, networkingStatusPendingAccept, peering.(networkingV1Peering).Status.Phase)
+
+	phase = networkingStatusReady
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+
+	phase = stateFailed
+	_, state, err = refresh()
+	require.EqualError(t, err, "[ERROR] Peering provisioning has failed: the VPC CIDR overlaps with the Network CIDR")
+	require.Equal(t, stateFailed, state)
+}
+
+func TestPeeringCloud(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   map[string]interface{}
+		cloud networkingV1PeeringCloud
+	}{
+		{
+			name: paramAws,
+			raw: map[string]interface{}{paramAws: []interface{}{map[string]interface{}{
+				paramAccount:        "012345678901",
+				paramVpc:            "vpc-abc123",
+				paramRoutes:         []interface{}{"172.31.0.0/16"},
+				paramCustomerRegion: "us-east-2",
+			}}},
+			cloud: networkingV1PeeringCloud{Kind: peeringKindAws, Account: "012345678901", Vpc: "vpc-abc123", Routes: []string{"172.31.0.0/16"}, CustomerRegion: "us-east-2"},
+		},
+		{
+			name: paramAzure,
+			raw: map[string]interface{}{paramAzure: []interface{}{map[string]interface{}{
+				paramTenant:         "1111tttt-1111-1111-1111-111111tttttt",
+				paramVnet:           "/subscriptions/1111ssss-1111-1111-1111-111111ssssss/resourceGroups/prod/providers/Microsoft.Network/virtualNetworks/prod",
+				paramCustomerRegion: "centralus",
+			}}},
+			cloud: networkingV1PeeringCloud{Kind: peeringKindAzure, Tenant: "1111tttt-1111-1111-1111-111111tttttt", Vnet: "/subscriptions/1111ssss-1111-1111-1111-111111ssssss/resourceGroups/prod/providers/Microsoft.Network/virtualNetworks/prod", CustomerRegion: "centralus"},
+		},
+		{
+			name: paramGcp,
+			raw: map[string]interface{}{paramGcp: []interface{}{map[string]interface{}{
+				paramProject:            "prod-project",
+				paramVpcNetwork:         "prod-network",
+				paramImportCustomRoutes: true,
+			}}},
+			cloud: networkingV1PeeringCloud{Kind: peeringKindGcp, Project: "prod-project", VpcNetwork: "prod-network", ImportCustomRoutes: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, peeringResource().Schema, test.raw)
+			cloud, err := extractPeeringCloud(d)
+			require.NoError(t, err)
+			require.Equal(t, test.cloud, *cloud)
+
+			// Reading the cloud back into an empty resource results in the same block
+			readD := schema.TestResourceDataRaw(t, peeringResource().Schema, map[string]interface{}{})
+			require.NoError(t, setPeeringCloud(readD, cloud))
+			readCloud, err := extractPeeringCloud(readD)
+			require.NoError(t, err)
+			require.Equal(t, test.cloud, *readCloud)
+		})
+	}
+}
+
+func TestPeeringDeleteStatus(t *testing.T) {
+	deleted := false
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/peerings/peer-abc123", r.URL.Path)
+		if deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "peer-abc123", "status": {"phase": "DEPROVISIONING"}}`))
+	})
+	refresh := peeringDeleteStatus(context.Background(), c, "env-abc123", "peer-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	deleted = true
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+}
+
+const testPeeringResponse = `{"id": "peer-abc123", "spec": {"display_name": "prod", "cloud": {"kind": "AwsPeering", "account": "012345678901", "vpc": "vpc-abc123", "routes": ["172.31.0.0/16"], "customer_region": "us-east-2"}, "environment": {"id": "env-abc123"}, "network": {"id": "n-abc123"}}, "status": {"phase": "PENDING_ACCEPT"}}`
+
+func testPeeringResourceData(t *testing.T) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, peeringResource().Schema, map[string]interface{}{
+		paramDisplayName: "prod",
+		paramAws: []interface{}{map[string]interface{}{
+			paramAccount:        "012345678901",
+			paramVpc:            "vpc-abc123",
+			paramRoutes:         []interface{}{"172.31.0.0/16"},
+			paramCustomerRegion: "us-east-2",
+		}},
+		paramEnvironment: []interface{}{map[string]interface{}{paramId: "env-abc123"}},
+		paramNetwork:     []interface{}{map[string]interface{}{paramId: "n-abc123"}},
+	})
+}
+
+func TestPeeringCreate(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/networking/v1/peerings":
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"spec": {"display_name": "prod", "cloud": {"kind": "AwsPeering", "account": "012345678901", "vpc": "vpc-abc123", "routes": ["172.31.0.0/16"], "customer_region": "us-east-2"}, "environment": {"id": "env-abc123"}, "network": {"id": "n-abc123"}}}`, string(body))
+			_, _ = w.Write([]byte(`{"id": "peer-abc123", "status": {"phase": "PROVISIONING"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/networking/v1/peerings/peer-abc123":
+			require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+			_, _ = w.Write([]byte(testPeeringResponse))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	d := testPeeringResourceData(t)
+
+	diags := peeringCreate(context.Background(), d, c)
+	require.False(t, diags.HasError())
+	require.Equal(t, "peer-abc123", d.Id())
+	require.Equal(t, "prod", d.Get(paramDisplayName))
+	require.Equal(t, "vpc-abc123", d.Get("aws.0.vpc"))
+	require.Equal(t, "n-abc123", d.Get("network.0.id"))
+
+	// A Peering that still has to be accepted on the cloud provider side is reported as a warning
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Equal(t, "Peering (peer-abc123) is PENDING_ACCEPT", diags[0].Summary)
+	require.Equal(t, pendingAcceptPeeringDetail(peeringKindAws), diags[0].Detail)
+}
+
+func TestPeeringUpdateDisplayName(t *testing.T) {
+	patched := false
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/peerings/peer-abc123", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPatch {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"spec": {"display_name": "prod", "environment": {"id": "env-abc123"}}}`, string(body))
+			patched = true
+			_, _ = w.Write([]byte(`{"id": "peer-abc123"}`))
+			return
+		}
+		require.Equal(t, http.MethodGet, r.Method)
+		_, _ = w.Write([]byte(testPeeringResponse))
+	})
+	d := testPeeringResourceData(t)
+	d.SetId("peer-abc123")
+
+	require.Empty(t, peeringUpdate(context.Background(), d, c))
+	require.True(t, patched)
+	require.Equal(t, "prod", d.Get(paramDisplayName))
+}
+
+func TestPeeringReadOfDeletedPeering(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/networking/v1/peerings/peer-abc123", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	d := testPeeringResourceData(t)
+	d.SetId("peer-abc123")
+	d.MarkNewResource()
+	// Only a Peering that was created before is removed from the state, a new one that can't be read is an error
+	require.True(t, peeringRead(context.Background(), d, c).HasError())
+
+	d = testPeeringResourceData(t)
+	d.SetId("peer-abc123")
+	require.Empty(t, peeringRead(context.Background(), d, c))
+	require.Empty(t, d.Id())
+}
+
+func TestPeeringDeleteOfDeletedPeering(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/networking/v1/peerings/peer-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+	d := testPeeringResourceData(t)
+	d.SetId("peer-abc123")
+
+	require.False(t, peeringDelete(context.Background(), d, c).HasError())
+}
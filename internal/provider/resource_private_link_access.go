@@ -0,0 +1,342 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramSubscription = "subscription"
+
+	privateLinkAccessKindAws   = "AwsPrivateLinkAccess"
+	privateLinkAccessKindAzure = "AzurePrivateLinkAccess"
+	privateLinkAccessKindGcp   = "GcpPrivateServiceConnectAccess"
+
+	privateLinkAccessesPath = "/networking/v1/private-link-accesses"
+
+	defaultPrivateLinkAccessCreateTimeout = 1 * time.Hour
+	defaultPrivateLinkAccessDeleteTimeout = 1 * time.Hour
+)
+
+// networkingV1PrivateLinkAccessCloud holds the fields of all 3 kinds of private link accesses, only the one of Kind is set.
+type networkingV1PrivateLinkAccessCloud struct {
+	Kind         string `json:"kind"`
+	Account      string `json:"account,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+	Project      string `json:"project,omitempty"`
+}
+
+type networkingV1PrivateLinkAccessSpec struct {
+	DisplayName string                              `json:"display_name,omitempty"`
+	Cloud       *networkingV1PrivateLinkAccessCloud `json:"cloud,omitempty"`
+	Environment *networkingV1ObjectReference        `json:"environment,omitempty"`
+	Network     *networkingV1ObjectReference        `json:"network,omitempty"`
+}
+
+type networkingV1PrivateLinkAccess struct {
+	ApiVersion string                            `json:"api_version,omitempty"`
+	Kind       string                            `json:"kind,omitempty"`
+	Id         string                            `json:"id,omitempty"`
+	Spec       networkingV1PrivateLinkAccessSpec `json:"spec"`
+	Status     networkingV1Status                `json:"status"`
+}
+
+func privateLinkAccessResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: privateLinkAccessCreate,
+		ReadContext:   privateLinkAccessRead,
+		UpdateContext: privateLinkAccessUpdate,
+		DeleteContext: privateLinkAccessDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: privateLinkAccessImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultPrivateLinkAccessCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultPrivateLinkAccessDeleteTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			paramApiVersion: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API Version defines the schema version of this representation of a Private Link Access.",
+			},
+			paramKind: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kind defines the object Private Link Access represents.",
+			},
+			paramDisplayName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The name of the Private Link Access.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramAws: privateLinkAccessCloudSchema(paramAccount, "The AWS account ID to allow for PrivateLink access.",
+				validation.StringMatch(awsAccountIdRegex, "the AWS account ID must consist of 12 digits")),
+			paramAzure: privateLinkAccessCloudSchema(paramSubscription, "The Azure subscription ID to allow for PrivateLink access.",
+				validation.IsUUID),
+			paramGcp: privateLinkAccessCloudSchema(paramProject, "The GCP project ID to allow for Private Service Connect access.",
+				validation.StringIsNotEmpty),
+			paramNetwork:     networkReferenceSchema(),
+			paramEnvironment: environmentSchema(),
+		},
+	}
+}
+
+// privateLinkAccessCloudSchema returns a cloud block, which consists of the single attribute that identifies
+// the AWS account, the Azure subscription or the GCP project.
+func privateLinkAccessCloudSchema(attribute, description string, validateFunc schema.SchemaValidateFunc) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				attribute: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  description,
+					ValidateFunc: validateFunc,
+				},
+			},
+		},
+		ExactlyOneOf: acceptedCloudBlocks,
+	}
+}
+
+func privateLinkAccessPath(privateLinkAccessId string) string {
+	return fmt.Sprintf("%s/%s", privateLinkAccessesPath, privateLinkAccessId)
+}
+
+func extractPrivateLinkAccessCloud(d *schema.ResourceData) (*networkingV1PrivateLinkAccessCloud, error) {
+	if aws := d.Get(paramAws).([]interface{}); len(aws) == 1 && aws[0] != nil {
+		return &networkingV1PrivateLinkAccessCloud{
+			Kind:    privateLinkAccessKindAws,
+			Account: aws[0].(map[string]interface{})[paramAccount].(string),
+		}, nil
+	}
+	if azure := d.Get(paramAzure).([]interface{}); len(azure) == 1 && azure[0] != nil {
+		return &networkingV1PrivateLinkAccessCloud{
+			Kind:         privateLinkAccessKindAzure,
+			Subscription: azure[0].(map[string]interface{})[paramSubscription].(string),
+		}, nil
+	}
+	if gcp := d.Get(paramGcp).([]interface{}); len(gcp) == 1 && gcp[0] != nil {
+		return &networkingV1PrivateLinkAccessCloud{
+			Kind:    privateLinkAccessKindGcp,
+			Project: gcp[0].(map[string]interface{})[paramProject].(string),
+		}, nil
+	}
+	return nil, fmt.Errorf("exactly one of %q, %q or %q blocks must be specified", paramAws, paramAzure, paramGcp)
+}
+
+func privateLinkAccessUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChangeExcept(paramDisplayName) {
+		return diag.Errorf("only %s field can be updated for a private link access", paramDisplayName)
+	}
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	c := meta.(*Client)
+	_, err = executeNetworkingDisplayNameUpdate(ctx, c, privateLinkAccessPath(d.Id()), environmentId, d.Get(paramDisplayName).(string))
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	return privateLinkAccessRead(ctx, d, meta)
+}
+
+func privateLinkAccessCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	cloud, err := extractPrivateLinkAccessCloud(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	spec := networkingV1PrivateLinkAccessSpec{
+		DisplayName: d.Get(paramDisplayName).(string),
+		Cloud:       cloud,
+		Environment: &networkingV1ObjectReference{Id: environmentId},
+		Network:     &networkingV1ObjectReference{Id: extractNetworkId(d)},
+	}
+
+	createdPrivateLinkAccess, resp, err := executePrivateLinkAccessCreate(ctx, c, spec)
+	if err != nil {
+		log.Printf("[ERROR] private link access create failed %+v, %v, %s", spec, resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+	d.SetId(createdPrivateLinkAccess.Id)
+	log.Printf("[DEBUG] Created private link access %s", createdPrivateLinkAccess.Id)
+
+	if err := waitForPrivateLinkAccessToProvision(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for private link access (%s) to provision: %s", d.Id(), err)
+	}
+
+	return privateLinkAccessRead(ctx, d, meta)
+}
+
+func executePrivateLinkAccessCreate(ctx context.Context, c *Client, spec networkingV1PrivateLinkAccessSpec) (networkingV1PrivateLinkAccess, *http.Response, error) {
+	var privateLinkAccess networkingV1PrivateLinkAccess
+	resp, err := c.legacyClient.Post(ctx, privateLinkAccessesPath, nil, networkingV1Request{Spec: spec}, &privateLinkAccess)
+	return privateLinkAccess, resp, err
+}
+
+func privateLinkAccessDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Private link access delete for %s", d.Id())
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	resp, err := c.legacyClient.Delete(ctx, privateLinkAccessPath(d.Id()), url.Values{"environment": {environmentId}}, nil, nil)
+	if HasStatusNotFound(resp) {
+		log.Printf("[INFO] Private link access %s is already deleted", d.Id())
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error deleting private link access (%s), err: %s", d.Id(), err)
+	}
+
+	if err := waitForPrivateLinkAccessToBeDeleted(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for private link access (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Private link access %s was deleted successfully", d.Id())
+
+	return nil
+}
+
+func executePrivateLinkAccessRead(ctx context.Context, c *Client, environmentId, privateLinkAccessId string) (networkingV1PrivateLinkAccess, *http.Response, error) {
+	var privateLinkAccess networkingV1PrivateLinkAccess
+	resp, err := c.legacyClient.Get(ctx, privateLinkAccessPath(privateLinkAccessId), url.Values{"environment": {environmentId}}, &privateLinkAccess)
+	return privateLinkAccess, resp, err
+}
+
+func privateLinkAccessRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Private link access read for %s", d.Id())
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	privateLinkAccess, resp, err := executePrivateLinkAccessRead(ctx, c, environmentId, d.Id())
+	if err != nil {
+		log.Printf("[WARN] Private link access get failed for id %s, %v, %s", d.Id(), resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Private link access with id=%s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
+
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := setPrivateLinkAccessAttributes(d, privateLinkAccess, environmentId); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	return nil
+}
+
+func setPrivateLinkAccessAttributes(d *schema.ResourceData, privateLinkAccess networkingV1PrivateLinkAccess, environmentId string) error {
+	if err := d.Set(paramApiVersion, privateLinkAccess.ApiVersion); err != nil {
+		return err
+	}
+	if err := d.Set(paramKind, privateLinkAccess.Kind); err != nil {
+		return err
+	}
+	if err := d.Set(paramDisplayName, privateLinkAccess.Spec.DisplayName); err != nil {
+		return err
+	}
+	if err := setPrivateLinkAccessCloud(d, privateLinkAccess.Spec.Cloud); err != nil {
+		return err
+	}
+	if privateLinkAccess.Spec.Network != nil {
+		if err := setNetworkId(privateLinkAccess.Spec.Network.Id, d); err != nil {
+			return err
+		}
+	}
+	return setEnvironmentId(environmentId, d)
+}
+
+func setPrivateLinkAccessCloud(d *schema.ResourceData, cloud *networkingV1PrivateLinkAccessCloud) error {
+	if err := clearCloudBlocks(d); err != nil {
+		return err
+	}
+	if cloud == nil {
+		return nil
+	}
+
+	switch cloud.Kind {
+	case privateLinkAccessKindAws:
+		return d.Set(paramAws, []interface{}{map[string]interface{}{paramAccount: cloud.Account}})
+	case privateLinkAccessKindAzure:
+		return d.Set(paramAzure, []interface{}{map[string]interface{}{paramSubscription: cloud.Subscription}})
+	case privateLinkAccessKindGcp:
+		return d.Set(paramGcp, []interface{}{map[string]interface{}{paramProject: cloud.Project}})
+	}
+	return fmt.Errorf("unknown private link access kind %q", cloud.Kind)
+}
+
+func privateLinkAccessImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	envIDAndPrivateLinkAccessID := d.Id()
+	parts := strings.Split(envIDAndPrivateLinkAccessID, "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format for private link access import: expected '<env ID>/<pla ID>'")
+	}
+
+	environmentId := parts[0]
+	privateLinkAccessId := parts[1]
+	d.SetId(privateLinkAccessId)
+	log.Printf("[INFO] Private link access import for %s", privateLinkAccessId)
+
+	if err := setEnvironmentId(environmentId, d); err != nil {
+		return nil, err
+	}
+	if diags := privateLinkAccessRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("error importing private link access (%s): %s", privateLinkAccessId, diags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateLinkAccessProvisionStatus(t *testing.T) {
+	phase := "PROVISIONING"
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/private-link-accesses/pla-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "pla-abc123", "status": {"phase": "` + phase + `"}}`))
+	})
+	refresh := privateLinkAccessProvisionStatus(context.Background(), c, "env-abc123", "pla-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	phase = networkingStatusReady
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+
+	phase = stateFailed
+	_, state, err = refresh()
+	require.EqualError(t, err, "[ERROR] Private link access provisioning has failed: no details were provided")
+	require.Equal(t, stateFailed, state)
+}
+
+func TestPrivateLinkAccessDeleteStatus(t *testing.T) {
+	deleted := false
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/private-link-accesses/pla-abc123", r.URL.Path)
+		if deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "pla-abc123", "status": {"phase": "DEPROVISIONING"}}`))
+	})
+	refresh := privateLinkAccessDeleteStatus(context.Background(), c, "env-abc123", "pla-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	deleted = true
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+}
+
+func TestExecutePrivateLinkAccessCreate(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/networking/v1/private-link-accesses", r.URL.Path)
+		var body struct {
+			Spec networkingV1PrivateLinkAccessSpec `json:"spec"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, &networkingV1PrivateLinkAccessCloud{Kind: privateLinkAccessKindAzure, Subscription: "1111ssss-1111-1111-1111-111111ssssss"}, body.Spec.Cloud)
+		require.Equal(t, "n-abc123", body.Spec.Network.Id)
+		require.Equal(t, "env-abc123", body.Spec.Environment.Id)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "pla-abc123", "status": {"phase": "PROVISIONING"}}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, privateLinkAccessResource().Schema, map[string]interface{}{
+		paramAzure: []interface{}{map[string]interface{}{paramSubscription: "1111ssss-1111-1111-1111-111111ssssss"}},
+	})
+	cloud, err := extractPrivateLinkAccessCloud(d)
+	require.NoError(t, err)
+
+	privateLinkAccess, _, err := executePrivateLinkAccessCreate(context.Background(), c, networkingV1PrivateLinkAccessSpec{
+		Cloud:       cloud,
+		Environment: &networkingV1ObjectReference{Id: "env-abc123"},
+		Network:     &networkingV1ObjectReference{Id: "n-abc123"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pla-abc123", privateLinkAccess.Id)
+}
+
+func TestSetPrivateLinkAccessCloud(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, privateLinkAccessResource().Schema, map[string]interface{}{
+		paramAws: []interface{}{map[string]interface{}{paramAccount: "012345678901"}},
+	})
+
+	require.NoError(t, setPrivateLinkAccessCloud(d, &networkingV1PrivateLinkAccessCloud{Kind: privateLinkAccessKindGcp, Project: "prod-project"}))
+	require.Empty(t, d.Get(paramAws))
+	require.Equal(t, "prod-project", d.Get("gcp.0.project"))
+
+	require.EqualError(t, setPrivateLinkAccessCloud(d, &networkingV1PrivateLinkAccessCloud{Kind: "UnknownAccess"}), `unknown private link access kind "UnknownAccess"`)
+}
+
+func TestPrivateLinkAccessDeleteOfDeletedPrivateLinkAccess(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/networking/v1/private-link-accesses/pla-abc123", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	d := schema.TestResourceDataRaw(t, privateLinkAccessResource().Schema, map[string]interface{}{
+		paramEnvironment: []interface{}{map[string]interface{}{paramId: "env-abc123"}},
+	})
+	d.SetId("pla-abc123")
+
+	require.False(t, privateLinkAccessDelete(context.Background(), d, c).HasError())
+}
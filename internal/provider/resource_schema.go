@@ -0,0 +1,265 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramSubjectName     = "subject_name"
+	paramFormat          = "format"
+	paramSchema          = "schema"
+	paramSchemaReference = "schema_reference"
+	paramSchemaId        = "schema_id"
+	paramVersion         = "version"
+	paramName            = "name"
+	paramHardDelete      = "hard_delete"
+)
+
+func resourceSchema() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: schemaCreate,
+		ReadContext:   schemaRead,
+		UpdateContext: schemaUpdate,
+		DeleteContext: schemaDelete,
+		Schema: map[string]*schema.Schema{
+			paramHttpEndpoint: schemaRegistryHttpEndpointSchema(),
+			paramCredentials:  credentialsSchema(),
+			paramSubjectName: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the subject (in other words, the namespace) the schema is registered under, for example, `orders-value`.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramFormat: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The format of the schema. Accepted values are: `AVRO`, `PROTOBUF` and `JSON`.",
+				ValidateFunc: validation.StringInSlice(acceptedSchemaFormats, false),
+			},
+			paramSchema: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The definition of the schema. Changing it registers a new version of the subject.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramSchemaReference: schemaReferenceSchema(),
+			paramHardDelete: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to permanently delete all versions of the subject on destroy instead of soft-deleting them.",
+			},
+			paramSchemaId: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The globally unique ID of the schema.",
+			},
+			paramVersion: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the subject the schema is registered as.",
+			},
+		},
+	}
+}
+
+func schemaReferenceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The schemas (from other subjects) that the schema references.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramName: {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The name of the reference, for example, the fully qualified name of an Avro record or the import path of a Protobuf file.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				paramSubjectName: {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The subject the referenced schema is registered under.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				paramVersion: {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "The version of the referenced subject.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func extractSchemaReferences(d *schema.ResourceData) []schemaReference {
+	var references []schemaReference
+	for _, r := range d.Get(paramSchemaReference).([]interface{}) {
+		reference := r.(map[string]interface{})
+		references = append(references, schemaReference{
+			Name:    reference[paramName].(string),
+			Subject: reference[paramSubjectName].(string),
+			Version: reference[paramVersion].(int),
+		})
+	}
+	return references
+}
+
+func flattenSchemaReferences(references []schemaReference) []interface{} {
+	result := make([]interface{}, len(references))
+	for i, reference := range references {
+		result[i] = map[string]interface{}{
+			paramName:        reference.Name,
+			paramSubjectName: reference.Subject,
+			paramVersion:     reference.Version,
+		}
+	}
+	return result
+}
+
+func schemaRegistryRestClientFromResourceData(d *schema.ResourceData, meta interface{}) (*SchemaRegistryRestClient, error) {
+	httpEndpoint := d.Get(paramHttpEndpoint).(string)
+	apiKey, apiSecret, err := extractClusterApiKeyAndApiSecret(d)
+	if err != nil {
+		return nil, err
+	}
+	return meta.(*Client).schemaRegistryRestClientFactory.CreateSchemaRegistryRestClient(httpEndpoint, apiKey, apiSecret), nil
+}
+
+func schemaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	subjectName := d.Get(paramSubjectName).(string)
+
+	registered, err := executeSchemaRegister(ctx, c, d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	d.SetId(subjectName)
+	log.Printf("[DEBUG] Registered schema %d as version %d of subject %s", registered.Id, registered.Version, subjectName)
+
+	return schemaRead(ctx, d, meta)
+}
+
+func executeSchemaRegister(ctx context.Context, c *SchemaRegistryRestClient, d *schema.ResourceData) (subjectVersion, error) {
+	subjectName := d.Get(paramSubjectName).(string)
+	request := newRegisterSchemaRequest(d.Get(paramFormat).(string), d.Get(paramSchema).(string), extractSchemaReferences(d))
+
+	registered, resp, err := c.registerSchema(ctx, subjectName, request)
+	if err != nil {
+		log.Printf("[ERROR] Schema registration failed for subject %s, %v, %s", subjectName, resp, err)
+		return subjectVersion{}, err
+	}
+	if err := d.Set(paramSchemaId, registered.Id); err != nil {
+		return subjectVersion{}, err
+	}
+	if err := d.Set(paramVersion, registered.Version); err != nil {
+		return subjectVersion{}, err
+	}
+	return registered, nil
+}
+
+func schemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Schema read for %s", d.Id())
+
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	schemaId := d.Get(paramSchemaId).(int)
+	registered, resp, err := c.readSchemaVersion(ctx, d.Id(), schemaId)
+	if err != nil {
+		log.Printf("[WARN] Schema %d get failed for subject %s, %v, %s", schemaId, d.Id(), resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Schema %d of subject %s is not found", schemaId, d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
+
+		return createDiagnosticsWithDetails(err)
+	}
+
+	// The Schema Registry normalizes schemas, so the definition from the configuration (which was
+	// registered as schema_id) is kept and the registry's copy only fills in a missing one.
+	if d.Get(paramSchema).(string) == "" {
+		if err := d.Set(paramSchema, registered.Schema); err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+	}
+	if err := d.Set(paramSubjectName, registered.Subject); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramFormat, registered.format()); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramSchemaReference, flattenSchemaReferences(registered.References)); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	if err := d.Set(paramVersion, registered.Version); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	return nil
+}
+
+func schemaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges(paramSchema, paramSchemaReference) {
+		c, err := schemaRegistryRestClientFromResourceData(d, meta)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		registered, err := executeSchemaRegister(ctx, c, d)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		log.Printf("[DEBUG] Registered schema %d as version %d of subject %s", registered.Id, registered.Version, d.Id())
+	}
+	return schemaRead(ctx, d, meta)
+}
+
+func schemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Schema delete for %s", d.Id())
+
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	resp, err := c.deleteSubject(ctx, d.Id(), d.Get(paramHardDelete).(bool))
+	if err != nil && !HasStatusNotFound(resp) {
+		return diag.Errorf("error deleting subject (%s), err: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Subject %s was deleted successfully", d.Id())
+
+	return nil
+}
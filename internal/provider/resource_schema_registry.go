@@ -1,25 +1,31 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	schemaRegistryPackageEssentials = "essentials"
+	schemaRegistryPackageAdvanced   = "advanced"
+
+	schemaRegistryStatusUp = "UP"
+)
+
+var acceptedSchemaRegistryPackages = []string{schemaRegistryPackageEssentials, schemaRegistryPackageAdvanced}
+
 func resourceSchemaRegistry() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSchemaRegistryCreate,
 		ReadContext:   resourceSchemaRegistryRead,
-		UpdateContext: resourceSchemaRegistryUpdate,
 		DeleteContext: resourceSchemaRegistryDelete,
 		Schema: map[string]*schema.Schema{
 			"id": &schema.Schema{
@@ -27,24 +33,56 @@ func resourceSchemaRegistry() *schema.Resource {
 				Computed: true,
 			},
 			"environment_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Environment that the Schema Registry belongs to.",
 			},
 			"kafka_cluster_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the Kafka cluster that backs the Schema Registry.",
+				Deprecated:  "kafka_cluster_id is computed and its configured value is ignored. Remove it from your configuration.",
+				// The configured value used to be required but was never sent to Confluent Cloud
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return true
+				},
 			},
 			"endpoint": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The HTTP endpoint of the Schema Registry.",
 			},
 			"service_provider": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The cloud service provider that runs the Schema Registry.",
 			},
 			"location": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The geography the Schema Registry runs in, for example, `us`.",
+			},
+			"package": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The billing package of the Schema Registry, either `essentials` or `advanced`.",
+				ValidateFunc: validation.StringInSlice(acceptedSchemaRegistryPackages, false),
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the Schema Registry, for example, `UP`.",
+			},
+			"max_schemas": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of schemas the Schema Registry can hold.",
 			},
 		},
 		Importer: &schema.ResourceImporter{
@@ -53,38 +91,31 @@ func resourceSchemaRegistry() *schema.Resource {
 	}
 }
 
-/*
-				"account_id": "env-18vqv",
-        "created": "2022-03-28T00:35:19.860568Z",
-        "endpoint": "https://psrc-1wydj.us-east-2.aws.confluent.cloud",
-        "id": "lsrc-o338zj",
-        "kafka_cluster_id": "lkc-415jz",
-        "max_schemas": 1000,
-        "modified": "2022-03-28T00:35:19.860568Z",
-        "name": "account schema-registry",
-        "org_resource_id": "8698e965-f21a-4fba-b440-170f630352d6",
-        "organization_id": 51445,
-        "physical_cluster_id": "psrc-1wydj",
-        "status": "UP"
-*/
-
-func resourceSchemaRegistryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+type schemaRegistryCluster struct {
+	Id                    string `json:"id"`
+	Name                  string `json:"name"`
+	KafkaClusterId        string `json:"kafka_cluster_id"`
+	Endpoint              string `json:"endpoint"`
+	Created               string `json:"created"`
+	Modified              string `json:"modified"`
+	Status                string `json:"status"`
+	PhysicalClusterId     string `json:"physical_cluster_id"`
+	AccountId             string `json:"account_id"`
+	OrganizationId        int    `json:"organization_id"`
+	MaxSchemas            int    `json:"max_schemas"`
+	OrgResourceId         string `json:"org_resource_id"`
+	Package               string `json:"package"`
+	ServiceProvider       string `json:"service_provider"`
+	ServiceProviderRegion string `json:"service_provider_region"`
+	Location              string `json:"location"`
+}
 
-	type schemaRegistryCluster struct {
-		Id                  string
-		Name                string
-		Kafka_cluster_id    string
-		Endpoint            string
-		Created             string
-		Modified            string
-		Status              string
-		Physical_cluster_id string
-		Account_id          string
-		Organization_id     int
-		Max_schemas         int
-		Org_resource_id     string
-	}
+type schemaRegistryClusterResponse struct {
+	Error   string
+	Cluster schemaRegistryCluster
+}
 
+func resourceSchemaRegistryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	type responseBody struct {
 		Cluster          schemaRegistryCluster
 		Credentials      string
@@ -97,147 +128,136 @@ func resourceSchemaRegistryCreate(ctx context.Context, d *schema.ResourceData, m
 		Location        string `json:"location"`
 		Name            string `json:"name"`
 		ServiceProvider string `json:"serviceProvider"`
+		Package         string `json:"package,omitempty"`
 	}
 
 	type request struct {
 		Config requestConfig `json:"config"`
 	}
 
-	var diags diag.Diagnostics
-
 	c := m.(*Client)
 
-	environment_id := d.Get("environment_id").(string)
+	environmentId := d.Get("environment_id").(string)
 	location := d.Get("location").(string)
-	service_provider := d.Get("service_provider").(string)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	url := fmt.Sprintf("%s/schema_registries?account_id=%s", "https://api.confluent.cloud", environment_id)
+	serviceProvider := d.Get("service_provider").(string)
 
-	post_data := request{
+	createRequest := request{
 		requestConfig{
-			AccountId:       environment_id,
+			AccountId:       environmentId,
 			Location:        location,
 			Name:            "account schema-registry",
-			ServiceProvider: service_provider,
+			ServiceProvider: serviceProvider,
+			Package:         strings.ToUpper(d.Get("package").(string)),
 		},
 	}
-	json_post_data, err := json.Marshal(post_data)
-	requestBody := bytes.NewBuffer(json_post_data)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, requestBody)
+	var resp responseBody
+	_, err := c.legacyClient.Post(ctx, "/schema_registries", url.Values{"account_id": {environmentId}}, createRequest, &resp)
 	if err != nil {
+		log.Printf("[ERROR] Schema Registry create failed for environment %s, %s", environmentId, err)
 		return diag.FromErr(err)
 	}
-	req.Header.Add("Content-type", "application/json")
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
-
-	r, err := client.Do(req)
-	if err != nil {
-		return diag.FromErr(err)
+	if resp.Error != "" {
+		return diag.Errorf("unexpected API response: %s", resp.Error)
 	}
-	defer r.Body.Close()
 
-	if r.StatusCode != 200 {
-		body, _ := ioutil.ReadAll(r.Body)
-		log.Printf("body: %v", body)
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
+	d.SetId(resp.Cluster.Id)
+	log.Printf("[DEBUG] Created Schema Registry %s", d.Id())
+
+	if err := waitForSchemaRegistryToProvision(ctx, c, environmentId, d.Id()); err != nil {
+		return diag.Errorf("error waiting for Schema Registry (%s) to provision: %s", d.Id(), err)
 	}
 
-	var resp responseBody
+	return resourceSchemaRegistryRead(ctx, d, m)
+}
 
-	err = json.NewDecoder(r.Body).Decode(&resp)
+func executeSchemaRegistryRead(ctx context.Context, c *Client, environmentId, schemaRegistryId string) (schemaRegistryCluster, *http.Response, error) {
+	var resp schemaRegistryClusterResponse
+	r, err := c.legacyClient.Get(ctx, fmt.Sprintf("/schema_registries/%s", schemaRegistryId), url.Values{"account_id": {environmentId}}, &resp)
 	if err != nil {
-		return diag.FromErr(err)
+		return schemaRegistryCluster{}, r, err
 	}
-
 	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+		return schemaRegistryCluster{}, r, fmt.Errorf("unexpected API response: %s", resp.Error)
 	}
-
-	d.Set("name", resp.Cluster.Name)
-	d.SetId(resp.Cluster.Id)
-
-	return diags
+	return resp.Cluster, r, nil
 }
 
 func resourceSchemaRegistryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-
-	type schemaRegistryCluster struct {
-		Id                  string
-		Name                string
-		Kafka_cluster_id    string
-		Endpoint            string
-		Created             string
-		Modified            string
-		Status              string
-		Physical_cluster_id string
-		Account_id          string
-		Organization_id     int
-		Max_schemas         int
-		Org_resource_id     string
-	}
-
-	type responseBody struct {
-		Error   string
-		Cluster schemaRegistryCluster
-	}
-
+	log.Printf("[INFO] Schema Registry read for %s", d.Id())
 	c := m.(*Client)
 
-	var diags diag.Diagnostics
+	environmentId := d.Get("environment_id").(string)
 
-	srId := d.Id()
-	environmentId := d.Get("environment_id")
+	cluster, resp, err := executeSchemaRegistryRead(ctx, c, environmentId, d.Id())
+	if err != nil {
+		log.Printf("[WARN] Schema Registry get failed for id %s, %v, %s", d.Id(), resp, err)
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	url := fmt.Sprintf("%s/schema_registries/%s?account_id=%s", "https://api.confluent.cloud", srId, environmentId)
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Schema Registry with id=%s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	req.SetBasicAuth(c.apiKey, c.apiSecret)
-	r, err := client.Do(req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer r.Body.Close()
+	return diag.FromErr(setSchemaRegistryAttributes(d, cluster))
+}
 
-	if r.StatusCode != 200 {
-		return append(diags, diag.Errorf("HTTP request error. Response code: %d", r.StatusCode)...)
+func setSchemaRegistryAttributes(d *schema.ResourceData, cluster schemaRegistryCluster) error {
+	if err := d.Set("endpoint", cluster.Endpoint); err != nil {
+		return err
 	}
-
-	var resp responseBody
-
-	err = json.NewDecoder(r.Body).Decode(&resp)
-	if err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("kafka_cluster_id", cluster.KafkaClusterId); err != nil {
+		return err
 	}
-
-	if resp.Error != "" {
-		return append(diags, diag.Errorf("Unexpected API response. Body: %v", resp)...)
+	if err := d.Set("status", cluster.Status); err != nil {
+		return err
 	}
-
-	d.Set("name", resp.Cluster.Name)
-
-	return diags
+	if err := d.Set("max_schemas", cluster.MaxSchemas); err != nil {
+		return err
+	}
+	if cluster.AccountId != "" {
+		if err := d.Set("environment_id", cluster.AccountId); err != nil {
+			return err
+		}
+	}
+	if cluster.Package != "" {
+		if err := d.Set("package", strings.ToLower(cluster.Package)); err != nil {
+			return err
+		}
+	}
+	if cluster.ServiceProvider != "" {
+		if err := d.Set("service_provider", cluster.ServiceProvider); err != nil {
+			return err
+		}
+	}
+	if cluster.Location != "" {
+		if err := d.Set("location", cluster.Location); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func resourceSchemaRegistryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-
-	var diags diag.Diagnostics
+func resourceSchemaRegistryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Schema Registry delete for %s", d.Id())
+	c := m.(*Client)
 
-	return diags
-}
+	environmentId := d.Get("environment_id").(string)
 
-func resourceSchemaRegistryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resp, err := c.legacyClient.Delete(ctx, fmt.Sprintf("/schema_registries/%s", d.Id()), url.Values{"account_id": {environmentId}}, nil, nil)
+	if err != nil && !HasStatusNotFound(resp) {
+		return diag.Errorf("error deleting Schema Registry (%s), err: %s", d.Id(), err)
+	}
 
-	var diags diag.Diagnostics
+	log.Printf("[INFO] Schema Registry %s was deleted successfully", d.Id())
 
-	return diags
+	return nil
 }
 
 func schemaRegistryImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -245,7 +265,7 @@ func schemaRegistryImport(ctx context.Context, d *schema.ResourceData, m interfa
 	parts := strings.Split(envIDAndClusterID, "/")
 
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid format for kafka import: expected '<env ID>/<lkc ID>'")
+		return nil, fmt.Errorf("invalid format for Schema Registry import: expected '<env ID>/<lsrc ID>'")
 	}
 
 	environmentId := parts[0]
@@ -0,0 +1,148 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchemaRegistryResponse = `{"cluster": {"id": "lsrc-abc123", "kafka_cluster_id": "lkc-abc123", "endpoint": "https://psrc-00000.us-central1.gcp.confluent.cloud", "status": "UP", "account_id": "env-abc123", "max_schemas": 1000, "package": "ADVANCED", "service_provider": "gcp", "location": "us"}}`
+
+func TestSchemaRegistryProvisionStatus(t *testing.T) {
+	status := "PROVISIONING"
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/schema_registries/lsrc-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"cluster": {"id": "lsrc-abc123", "status": "` + status + `"}}`))
+	})
+	refresh := schemaRegistryProvisionStatus(context.Background(), c, "env-abc123", "lsrc-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	// The status is compared case-insensitively
+	status = "up"
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+
+	status = stateFailed
+	_, state, err = refresh()
+	require.EqualError(t, err, "[ERROR] Schema Registry provisioning has failed")
+	require.Equal(t, stateFailed, state)
+}
+
+func TestResourceSchemaRegistryCreate(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/schema_registries":
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"config": {"accountId": "env-abc123", "location": "us", "name": "account schema-registry", "serviceProvider": "gcp", "package": "ADVANCED"}}`, string(body))
+			_, _ = w.Write([]byte(`{"cluster": {"id": "lsrc-abc123", "status": "PROVISIONING"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/schema_registries/lsrc-abc123":
+			_, _ = w.Write([]byte(testSchemaRegistryResponse))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSchemaRegistry().Schema, map[string]interface{}{
+		"environment_id":   "env-abc123",
+		"service_provider": "gcp",
+		"location":         "us",
+		"package":          schemaRegistryPackageAdvanced,
+	})
+	require.Empty(t, resourceSchemaRegistryCreate(context.Background(), d, c))
+	require.Equal(t, "lsrc-abc123", d.Id())
+	require.Equal(t, "https://psrc-00000.us-central1.gcp.confluent.cloud", d.Get("endpoint"))
+	require.Equal(t, "lkc-abc123", d.Get("kafka_cluster_id"))
+	require.Equal(t, "UP", d.Get("status"))
+	require.Equal(t, 1000, d.Get("max_schemas"))
+}
+
+func TestResourceSchemaRegistryRead(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		if r.URL.Path != "/schema_registries/lsrc-abc123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testSchemaRegistryResponse))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSchemaRegistry().Schema, map[string]interface{}{"environment_id": "env-abc123"})
+	d.SetId("lsrc-abc123")
+	require.Empty(t, resourceSchemaRegistryRead(context.Background(), d, c))
+	require.Equal(t, "lsrc-abc123", d.Id())
+	require.Equal(t, schemaRegistryPackageAdvanced, d.Get("package"))
+	require.Equal(t, "gcp", d.Get("service_provider"))
+	require.Equal(t, "us", d.Get("location"))
+
+	// A Schema Registry that was deleted outside of Terraform is removed from the state
+	d.SetId("lsrc-def456")
+	require.Empty(t, resourceSchemaRegistryRead(context.Background(), d, c))
+	require.Empty(t, d.Id())
+}
+
+func TestResourceSchemaRegistryDelete(t *testing.T) {
+	var deletedPaths []string
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("account_id"))
+		deletedPaths = append(deletedPaths, r.URL.Path)
+		if r.URL.Path != "/schema_registries/lsrc-abc123" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceSchemaRegistry().Schema, map[string]interface{}{"environment_id": "env-abc123"})
+	d.SetId("lsrc-abc123")
+	require.Empty(t, resourceSchemaRegistryDelete(context.Background(), d, c))
+
+	// A Schema Registry that doesn't exist anymore is considered deleted
+	d.SetId("lsrc-def456")
+	require.Empty(t, resourceSchemaRegistryDelete(context.Background(), d, c))
+	require.Equal(t, []string{"/schema_registries/lsrc-abc123", "/schema_registries/lsrc-def456"}, deletedPaths)
+}
+
+func TestSchemaRegistryImport(t *testing.T) {
+	d := resourceSchemaRegistry().Data(nil)
+	d.SetId("env-abc123/lsrc-abc123")
+	imported, err := schemaRegistryImport(context.Background(), d, nil)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, "lsrc-abc123", d.Id())
+	require.Equal(t, "env-abc123", d.Get("environment_id"))
+
+	for _, id := range []string{"lsrc-abc123", "env-abc123/lsrc-abc123/extra"} {
+		d := resourceSchemaRegistry().Data(nil)
+		d.SetId(id)
+		_, err := schemaRegistryImport(context.Background(), d, nil)
+		require.EqualError(t, err, "invalid format for Schema Registry import: expected '<env ID>/<lsrc ID>'")
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchemaDefinition = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`
+
+// newTestSchemaRegistryServer fakes a Schema Registry where schema 100001 is version 1 of the orders-value subject
+// and schema 100002 is its (latest) version 2, until the subject is deleted.
+func newTestSchemaRegistryServer(t *testing.T, requests *[]string) *httptest.Server {
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		if deleted {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40401, "message": "Subject 'orders-value' not found."}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /subjects/orders-value/versions":
+			var request registerSchemaRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			require.Equal(t, testSchemaDefinition, request.Schema)
+			require.Empty(t, request.SchemaType)
+			_, _ = w.Write([]byte(`{"id": 100001}`))
+		case "POST /subjects/orders-value":
+			_, _ = w.Write([]byte(`{"subject": "orders-value", "id": 100001, "version": 1, "schema": "{\"type\":\"record\",\"name\":\"Order\",\"fields\":[{\"name\":\"id\",\"type\":\"string\"}]}"}`))
+		case "GET /schemas/ids/100001":
+			_, _ = w.Write([]byte(`{"schema": "{\"type\":\"record\",\"name\":\"Order\",\"fields\":[{\"name\":\"id\",\"type\":\"string\"}]}"}`))
+		case "GET /schemas/ids/100001/versions":
+			_, _ = w.Write([]byte(`[{"subject": "orders-value", "version": 1}, {"subject": "orders-archive-value", "version": 4}]`))
+		case "GET /schemas/ids/100002":
+			_, _ = w.Write([]byte(`{"schema": "{\"type\":\"record\",\"name\":\"Order\",\"fields\":[{\"name\":\"id\",\"type\":\"long\"}]}"}`))
+		case "GET /schemas/ids/100002/versions":
+			_, _ = w.Write([]byte(`[{"subject": "orders-archive-value", "version": 5}]`))
+		case "DELETE /subjects/orders-value":
+			deleted = true
+			_, _ = w.Write([]byte(`[1, 2]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testSchemaResourceData(t *testing.T, httpEndpoint string) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourceSchema().Schema, map[string]interface{}{
+		paramHttpEndpoint: httpEndpoint,
+		paramCredentials:  []interface{}{map[string]interface{}{paramKey: "foo", paramSecret: "bar"}},
+		paramSubjectName:  testSchemaRegistrySubjectName,
+		paramFormat:       schemaFormatAvro,
+		paramSchema:       testSchemaDefinition,
+	})
+}
+
+func TestSchemaCreate(t *testing.T) {
+	var requests []string
+	server := newTestSchemaRegistryServer(t, &requests)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+	d := testSchemaResourceData(t, server.URL)
+
+	require.Empty(t, schemaCreate(context.Background(), d, meta))
+	require.Equal(t, testSchemaRegistrySubjectName, d.Id())
+	require.Equal(t, 100001, d.Get(paramSchemaId))
+	require.Equal(t, 1, d.Get(paramVersion))
+	require.Equal(t, schemaFormatAvro, d.Get(paramFormat))
+	// The definition from the configuration is kept rather than the one normalized by the Schema Registry
+	require.Equal(t, testSchemaDefinition, d.Get(paramSchema))
+	require.Equal(t, []string{
+		"POST /subjects/orders-value/versions",
+		"POST /subjects/orders-value",
+		"GET /schemas/ids/100001",
+		"GET /schemas/ids/100001/versions",
+	}, requests)
+}
+
+func TestSchemaRead(t *testing.T) {
+	var requests []string
+	server := newTestSchemaRegistryServer(t, &requests)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+
+	// A later version of the subject registered outside of Terraform doesn't change the tracked version
+	d := testSchemaResourceData(t, server.URL)
+	d.SetId(testSchemaRegistrySubjectName)
+	require.NoError(t, d.Set(paramSchemaId, 100001))
+	require.Empty(t, schemaRead(context.Background(), d, meta))
+	require.Equal(t, testSchemaRegistrySubjectName, d.Id())
+	require.Equal(t, testSchemaDefinition, d.Get(paramSchema))
+	require.Equal(t, 100001, d.Get(paramSchemaId))
+	require.Equal(t, 1, d.Get(paramVersion))
+
+	// A definition that's missing from the state is read from the Schema Registry
+	require.NoError(t, d.Set(paramSchema, ""))
+	require.Empty(t, schemaRead(context.Background(), d, meta))
+	require.Equal(t, `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`, d.Get(paramSchema))
+
+	// A schema that isn't a version of the subject anymore is removed from the state
+	require.NoError(t, d.Set(paramSchemaId, 100002))
+	require.Empty(t, schemaRead(context.Background(), d, meta))
+	require.Empty(t, d.Id())
+
+	// So is a schema that doesn't exist anymore
+	d.SetId(testSchemaRegistrySubjectName)
+	require.NoError(t, d.Set(paramSchemaId, 100003))
+	require.Empty(t, schemaRead(context.Background(), d, meta))
+	require.Empty(t, d.Id())
+}
+
+func TestSchemaDelete(t *testing.T) {
+	var requests []string
+	server := newTestSchemaRegistryServer(t, &requests)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+	d := testSchemaResourceData(t, server.URL)
+	d.SetId(testSchemaRegistrySubjectName)
+
+	require.Empty(t, schemaDelete(context.Background(), d, meta))
+
+	// A subject that doesn't exist anymore is considered deleted
+	require.Empty(t, schemaDelete(context.Background(), d, meta))
+	require.Equal(t, []string{"DELETE /subjects/orders-value", "DELETE /subjects/orders-value"}, requests)
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramCompatibilityLevel = "compatibility_level"
+
+	// globalSubjectConfigId is the ID of subject config and subject mode resources that manage the registry-wide setting
+	globalSubjectConfigId = "global"
+)
+
+func resourceSubjectConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: subjectConfigCreate,
+		ReadContext:   subjectConfigRead,
+		UpdateContext: subjectConfigUpdate,
+		DeleteContext: subjectConfigDelete,
+		Schema: map[string]*schema.Schema{
+			paramHttpEndpoint: schemaRegistryHttpEndpointSchema(),
+			paramCredentials:  credentialsSchema(),
+			paramSubjectName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The name of the subject, for example, `orders-value`. Omit it to manage the registry-wide default.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramCompatibilityLevel: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The compatibility level. Accepted values are: `BACKWARD`, `BACKWARD_TRANSITIVE`, `FORWARD`, `FORWARD_TRANSITIVE`, `FULL`, `FULL_TRANSITIVE` and `NONE`.",
+				ValidateFunc: validation.StringInSlice(acceptedCompatibilityLevels, false),
+			},
+		},
+	}
+}
+
+func schemaRegistryHttpEndpointSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		Description:  "The REST endpoint of the Schema Registry cluster, for example, `https://psrc-00000.us-central1.gcp.confluent.cloud`.",
+		ValidateFunc: validation.IsURLWithHTTPS,
+	}
+}
+
+// subjectConfigId returns the subject name, or "global" for the registry-wide setting.
+func subjectConfigId(subjectName string) string {
+	if subjectName == "" {
+		return globalSubjectConfigId
+	}
+	return subjectName
+}
+
+func subjectConfigCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	subjectName := d.Get(paramSubjectName).(string)
+	compatibilityLevel := d.Get(paramCompatibilityLevel).(string)
+
+	resp, err := c.updateCompatibilityLevel(ctx, subjectName, compatibilityLevel)
+	if err != nil {
+		log.Printf("[ERROR] Compatibility level update failed for %s, %v, %s", subjectConfigId(subjectName), resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+
+	d.SetId(subjectConfigId(subjectName))
+	log.Printf("[DEBUG] Set compatibility level of %s to %s", d.Id(), compatibilityLevel)
+
+	return subjectConfigRead(ctx, d, meta)
+}
+
+func subjectConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Subject config read for %s", d.Id())
+
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	compatibilityLevel, resp, err := c.readCompatibilityLevel(ctx, d.Get(paramSubjectName).(string))
+	if err != nil {
+		log.Printf("[WARN] Subject config get failed for %s, %v, %s", d.Id(), resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Subject config for %s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
+
+		return createDiagnosticsWithDetails(err)
+	}
+
+	if err := d.Set(paramCompatibilityLevel, compatibilityLevel); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	return nil
+}
+
+func subjectConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange(paramCompatibilityLevel) {
+		c, err := schemaRegistryRestClientFromResourceData(d, meta)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		compatibilityLevel := d.Get(paramCompatibilityLevel).(string)
+		resp, err := c.updateCompatibilityLevel(ctx, d.Get(paramSubjectName).(string), compatibilityLevel)
+		if err != nil {
+			log.Printf("[ERROR] Compatibility level update failed for %s, %v, %s", d.Id(), resp, err)
+			return createDiagnosticsWithDetails(err)
+		}
+		log.Printf("[DEBUG] Set compatibility level of %s to %s", d.Id(), compatibilityLevel)
+	}
+	return subjectConfigRead(ctx, d, meta)
+}
+
+func subjectConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Subject config delete for %s", d.Id())
+
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	subjectName := d.Get(paramSubjectName).(string)
+	if subjectName == "" {
+		// The registry-wide compatibility level can't be removed, so it's reset to the Schema Registry default
+		_, err = c.updateCompatibilityLevel(ctx, subjectName, compatibilityLevelBackward)
+	} else {
+		// The subject falls back to the registry-wide compatibility level
+		var resp *http.Response
+		resp, err = c.deleteCompatibilityLevel(ctx, subjectName)
+		if HasStatusNotFound(resp) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return diag.Errorf("error deleting subject config (%s), err: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Subject config %s was deleted successfully", d.Id())
+
+	return nil
+}
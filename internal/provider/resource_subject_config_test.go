@@ -0,0 +1,112 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSubjectConfigServer fakes the config endpoints of a Schema Registry, keyed by path ("/config" or "/config/<subject>").
+func newTestSubjectConfigServer(t *testing.T, configs map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.URL.Path, "/config"))
+		w.Header().Set("Content-Type", "application/json")
+		compatibilityLevel, ok := configs[r.URL.Path]
+		switch r.Method {
+		case http.MethodPut:
+			var request struct {
+				Compatibility string `json:"compatibility"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			configs[r.URL.Path] = request.Compatibility
+			_, _ = w.Write([]byte(`{"compatibility": "` + request.Compatibility + `"}`))
+			return
+		case http.MethodDelete:
+			delete(configs, r.URL.Path)
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40408, "message": "Subject does not have subject-level compatibility configured"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"compatibilityLevel": "` + compatibilityLevel + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testSubjectConfigResourceData(t *testing.T, httpEndpoint, subjectName, compatibilityLevel string) *schema.ResourceData {
+	raw := map[string]interface{}{
+		paramHttpEndpoint:       httpEndpoint,
+		paramCredentials:        []interface{}{map[string]interface{}{paramKey: "foo", paramSecret: "bar"}},
+		paramCompatibilityLevel: compatibilityLevel,
+	}
+	if subjectName != "" {
+		raw[paramSubjectName] = subjectName
+	}
+	return schema.TestResourceDataRaw(t, resourceSubjectConfig().Schema, raw)
+}
+
+func TestSubjectConfigCreateReadDelete(t *testing.T) {
+	configs := map[string]string{"/config": compatibilityLevelBackward}
+	server := newTestSubjectConfigServer(t, configs)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+	d := testSubjectConfigResourceData(t, server.URL, testSchemaRegistrySubjectName, "FULL_TRANSITIVE")
+
+	require.Empty(t, subjectConfigCreate(context.Background(), d, meta))
+	require.Equal(t, testSchemaRegistrySubjectName, d.Id())
+	require.Equal(t, "FULL_TRANSITIVE", d.Get(paramCompatibilityLevel))
+	require.Equal(t, map[string]string{"/config": compatibilityLevelBackward, "/config/orders-value": "FULL_TRANSITIVE"}, configs)
+
+	// A compatibility level changed outside of Terraform is read back
+	configs["/config/orders-value"] = "NONE"
+	require.Empty(t, subjectConfigRead(context.Background(), d, meta))
+	require.Equal(t, "NONE", d.Get(paramCompatibilityLevel))
+
+	// The subject falls back to the registry-wide compatibility level, which is left untouched
+	require.Empty(t, subjectConfigDelete(context.Background(), d, meta))
+	require.Equal(t, map[string]string{"/config": compatibilityLevelBackward}, configs)
+
+	// A subject config that doesn't exist anymore is considered deleted
+	require.Empty(t, subjectConfigDelete(context.Background(), d, meta))
+
+	// And it's removed from the state
+	require.Empty(t, subjectConfigRead(context.Background(), d, meta))
+	require.Empty(t, d.Id())
+}
+
+func TestSubjectConfigGlobalCreateDelete(t *testing.T) {
+	configs := map[string]string{"/config": compatibilityLevelBackward}
+	server := newTestSubjectConfigServer(t, configs)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+	d := testSubjectConfigResourceData(t, server.URL, "", "FORWARD")
+
+	require.Empty(t, subjectConfigCreate(context.Background(), d, meta))
+	require.Equal(t, globalSubjectConfigId, d.Id())
+	require.Equal(t, "FORWARD", d.Get(paramCompatibilityLevel))
+	require.Equal(t, map[string]string{"/config": "FORWARD"}, configs)
+
+	// The registry-wide compatibility level is reset to the Schema Registry default
+	require.Empty(t, subjectConfigDelete(context.Background(), d, meta))
+	require.Equal(t, map[string]string{"/config": compatibilityLevelBackward}, configs)
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramMode  = "mode"
+	paramForce = "force"
+)
+
+func resourceSubjectMode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: subjectModeCreate,
+		ReadContext:   subjectModeRead,
+		UpdateContext: subjectModeUpdate,
+		DeleteContext: subjectModeDelete,
+		Schema: map[string]*schema.Schema{
+			paramHttpEndpoint: schemaRegistryHttpEndpointSchema(),
+			paramCredentials:  credentialsSchema(),
+			paramSubjectName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The name of the subject, for example, `orders-value`. Omit it to manage the registry-wide mode.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramMode: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The mode. Accepted values are: `READWRITE`, `READONLY` and `IMPORT`.",
+				ValidateFunc: validation.StringInSlice(acceptedSubjectModes, false),
+			},
+			paramForce: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to switch to `IMPORT` mode even if the registry (or the subject) already contains schemas.",
+			},
+		},
+	}
+}
+
+func subjectModeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	subjectName := d.Get(paramSubjectName).(string)
+
+	if err := executeSubjectModeUpdate(ctx, c, subjectName, d.Get(paramMode).(string), d.Get(paramForce).(bool)); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	d.SetId(subjectConfigId(subjectName))
+
+	return subjectModeRead(ctx, d, meta)
+}
+
+func executeSubjectModeUpdate(ctx context.Context, c *SchemaRegistryRestClient, subjectName, mode string, force bool) error {
+	resp, err := c.updateMode(ctx, subjectName, mode, force)
+	if err != nil {
+		log.Printf("[ERROR] Mode update failed for %s, %v, %s", subjectConfigId(subjectName), resp, err)
+		return err
+	}
+	log.Printf("[DEBUG] Set mode of %s to %s", subjectConfigId(subjectName), mode)
+	return nil
+}
+
+func subjectModeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Subject mode read for %s", d.Id())
+
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	mode, resp, err := c.readMode(ctx, d.Get(paramSubjectName).(string))
+	if err != nil {
+		log.Printf("[WARN] Subject mode get failed for %s, %v, %s", d.Id(), resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Subject mode for %s is not found", d.Id())
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil
+		}
+
+		return createDiagnosticsWithDetails(err)
+	}
+
+	if err := d.Set(paramMode, mode); err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+	return nil
+}
+
+func subjectModeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange(paramMode) {
+		c, err := schemaRegistryRestClientFromResourceData(d, meta)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+		if err := executeSubjectModeUpdate(ctx, c, d.Get(paramSubjectName).(string), d.Get(paramMode).(string), d.Get(paramForce).(bool)); err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+	}
+	return subjectModeRead(ctx, d, meta)
+}
+
+func subjectModeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Subject mode delete for %s", d.Id())
+
+	c, err := schemaRegistryRestClientFromResourceData(d, meta)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	// Destroying the resource puts the subject (or the registry) back into the default READWRITE mode
+	if err := executeSubjectModeUpdate(ctx, c, d.Get(paramSubjectName).(string), subjectModeReadWrite, false); err != nil {
+		return diag.Errorf("error restoring %s mode of %s, err: %s", subjectModeReadWrite, d.Id(), err)
+	}
+
+	log.Printf("[INFO] Subject mode %s was deleted successfully", d.Id())
+
+	return nil
+}
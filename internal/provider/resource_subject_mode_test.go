@@ -0,0 +1,115 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSubjectModeServer fakes the mode endpoints of a Schema Registry, keyed by path ("/mode" or "/mode/<subject>"),
+// and records the force flag of every mode update.
+func newTestSubjectModeServer(t *testing.T, modes map[string]string, forceFlags *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.URL.Path, "/mode"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			var request struct {
+				Mode string `json:"mode"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			modes[r.URL.Path] = request.Mode
+			*forceFlags = append(*forceFlags, r.URL.Query().Get("force"))
+			_, _ = w.Write([]byte(`{"mode": "` + request.Mode + `"}`))
+			return
+		}
+		require.Equal(t, http.MethodGet, r.Method)
+		mode, ok := modes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40409, "message": "Subject does not have subject-level mode configured"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"mode": "` + mode + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testSubjectModeResourceData(t *testing.T, httpEndpoint, subjectName, mode string, force bool) *schema.ResourceData {
+	raw := map[string]interface{}{
+		paramHttpEndpoint: httpEndpoint,
+		paramCredentials:  []interface{}{map[string]interface{}{paramKey: "foo", paramSecret: "bar"}},
+		paramMode:         mode,
+		paramForce:        force,
+	}
+	if subjectName != "" {
+		raw[paramSubjectName] = subjectName
+	}
+	return schema.TestResourceDataRaw(t, resourceSubjectMode().Schema, raw)
+}
+
+func TestSubjectModeCreateReadDelete(t *testing.T) {
+	modes := map[string]string{"/mode": subjectModeReadWrite}
+	var forceFlags []string
+	server := newTestSubjectModeServer(t, modes, &forceFlags)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+	d := testSubjectModeResourceData(t, server.URL, testSchemaRegistrySubjectName, "READONLY", false)
+
+	require.Empty(t, subjectModeCreate(context.Background(), d, meta))
+	require.Equal(t, testSchemaRegistrySubjectName, d.Id())
+	require.Equal(t, "READONLY", d.Get(paramMode))
+	require.Equal(t, map[string]string{"/mode": subjectModeReadWrite, "/mode/orders-value": "READONLY"}, modes)
+
+	// A mode changed outside of Terraform is read back
+	modes["/mode/orders-value"] = "IMPORT"
+	require.Empty(t, subjectModeRead(context.Background(), d, meta))
+	require.Equal(t, "IMPORT", d.Get(paramMode))
+
+	// Destroying the resource puts the subject back into READWRITE mode, without forcing it
+	require.Empty(t, subjectModeDelete(context.Background(), d, meta))
+	require.Equal(t, map[string]string{"/mode": subjectModeReadWrite, "/mode/orders-value": subjectModeReadWrite}, modes)
+	require.Equal(t, []string{"", ""}, forceFlags)
+
+	// A subject mode that doesn't exist anymore is removed from the state
+	delete(modes, "/mode/orders-value")
+	require.Empty(t, subjectModeRead(context.Background(), d, meta))
+	require.Empty(t, d.Id())
+}
+
+func TestSubjectModeGlobalCreateDelete(t *testing.T) {
+	modes := map[string]string{"/mode": subjectModeReadWrite}
+	var forceFlags []string
+	server := newTestSubjectModeServer(t, modes, &forceFlags)
+	meta := &Client{schemaRegistryRestClientFactory: &SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}}
+	d := testSubjectModeResourceData(t, server.URL, "", "IMPORT", true)
+
+	require.Empty(t, subjectModeCreate(context.Background(), d, meta))
+	require.Equal(t, globalSubjectConfigId, d.Id())
+	require.Equal(t, "IMPORT", d.Get(paramMode))
+	require.Equal(t, map[string]string{"/mode": "IMPORT"}, modes)
+
+	// The registry-wide mode is reset to the default READWRITE mode
+	require.Empty(t, subjectModeDelete(context.Background(), d, meta))
+	require.Equal(t, map[string]string{"/mode": subjectModeReadWrite}, modes)
+	require.Equal(t, []string{"true", ""}, forceFlags)
+}
@@ -0,0 +1,324 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paramRamShareArn                = "ram_share_arn"
+	paramTransitGatewayId           = "transit_gateway_id"
+	paramTransitGatewayAttachmentId = "transit_gateway_attachment_id"
+
+	transitGatewayAttachmentKindAws = "AwsTransitGatewayAttachment"
+
+	transitGatewayAttachmentsPath = "/networking/v1/transit-gateway-attachments"
+
+	defaultTransitGatewayAttachmentCreateTimeout = 1 * time.Hour
+	defaultTransitGatewayAttachmentDeleteTimeout = 1 * time.Hour
+)
+
+type networkingV1TransitGatewayAttachmentCloud struct {
+	Kind             string   `json:"kind"`
+	RamShareArn      string   `json:"ram_share_arn,omitempty"`
+	TransitGatewayId string   `json:"transit_gateway_id,omitempty"`
+	Routes           []string `json:"routes,omitempty"`
+}
+
+type networkingV1TransitGatewayAttachmentSpec struct {
+	DisplayName string                                     `json:"display_name,omitempty"`
+	Cloud       *networkingV1TransitGatewayAttachmentCloud `json:"cloud,omitempty"`
+	Environment *networkingV1ObjectReference               `json:"environment,omitempty"`
+	Network     *networkingV1ObjectReference               `json:"network,omitempty"`
+}
+
+type networkingV1TransitGatewayAttachmentStatus struct {
+	networkingV1Status
+	Cloud struct {
+		// The ID of the attachment that AWS created for the transit gateway
+		TransitGatewayAttachmentId string `json:"transit_gateway_attachment_id"`
+	} `json:"cloud"`
+}
+
+type networkingV1TransitGatewayAttachment struct {
+	Id     string                                     `json:"id,omitempty"`
+	Spec   networkingV1TransitGatewayAttachmentSpec   `json:"spec"`
+	Status networkingV1TransitGatewayAttachmentStatus `json:"status"`
+}
+
+func transitGatewayAttachmentResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: transitGatewayAttachmentCreate,
+		ReadContext:   transitGatewayAttachmentRead,
+		UpdateContext: transitGatewayAttachmentUpdate,
+		DeleteContext: transitGatewayAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: transitGatewayAttachmentImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTransitGatewayAttachmentCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultTransitGatewayAttachmentDeleteTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			paramDisplayName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The name of the Transit Gateway Attachment.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			paramAws:         awsTransitGatewayAttachmentSchema(),
+			paramNetwork:     networkReferenceSchema(),
+			paramEnvironment: environmentSchema(),
+		},
+	}
+}
+
+func awsTransitGatewayAttachmentSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				paramRamShareArn: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ARN of the AWS Resource Access Manager (RAM) share of the transit gateway.",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^arn:aws:ram:"), "the RAM share ARN must be of the form 'arn:aws:ram:'"),
+				},
+				paramTransitGatewayId: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The ID of the AWS transit gateway to attach the Network to.",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^tgw-"), "the transit gateway ID must be of the form 'tgw-'"),
+				},
+				paramRoutes: {
+					Type: schema.TypeList,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+					Required:    true,
+					ForceNew:    true,
+					MinItems:    1,
+					Description: "The CIDR blocks that Confluent Cloud routes to the transit gateway.",
+				},
+				paramTransitGatewayAttachmentId: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the attachment that AWS created for the transit gateway.",
+				},
+			},
+		},
+	}
+}
+
+func transitGatewayAttachmentPath(transitGatewayAttachmentId string) string {
+	return fmt.Sprintf("%s/%s", transitGatewayAttachmentsPath, transitGatewayAttachmentId)
+}
+
+func extractTransitGatewayAttachmentCloud(d *schema.ResourceData) *networkingV1TransitGatewayAttachmentCloud {
+	awsMap := d.Get(paramAws).([]interface{})[0].(map[string]interface{})
+	return &networkingV1TransitGatewayAttachmentCloud{
+		Kind:             transitGatewayAttachmentKindAws,
+		RamShareArn:      awsMap[paramRamShareArn].(string),
+		TransitGatewayId: awsMap[paramTransitGatewayId].(string),
+		Routes:           convertToStringSlice(awsMap[paramRoutes].([]interface{})),
+	}
+}
+
+func transitGatewayAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	spec := networkingV1TransitGatewayAttachmentSpec{
+		DisplayName: d.Get(paramDisplayName).(string),
+		Cloud:       extractTransitGatewayAttachmentCloud(d),
+		Environment: &networkingV1ObjectReference{Id: environmentId},
+		Network:     &networkingV1ObjectReference{Id: extractNetworkId(d)},
+	}
+	log.Printf("[DEBUG] Creating Transit Gateway Attachment with spec %+v", spec)
+
+	var transitGatewayAttachment networkingV1TransitGatewayAttachment
+	resp, err := c.legacyClient.Post(ctx, transitGatewayAttachmentsPath, nil, networkingV1Request{Spec: spec}, &transitGatewayAttachment)
+	if err != nil {
+		log.Printf("[ERROR] Transit Gateway Attachment create failed %v, %s", resp, err)
+		return createDiagnosticsWithDetails(err)
+	}
+	d.SetId(transitGatewayAttachment.Id)
+
+	log.Printf("[DEBUG] Created Transit Gateway Attachment %s", d.Id())
+
+	phase, err := waitForTransitGatewayAttachmentToProvision(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error waiting for Transit Gateway Attachment (%s) to provision: %s", d.Id(), err)
+	}
+
+	diags := transitGatewayAttachmentRead(ctx, d, meta)
+	if phase == networkingStatusPendingAccept {
+		// Transit gateways that don't auto-accept shared attachments need the attachment to be accepted in AWS
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Transit Gateway Attachment (%s) is %s", d.Id(), networkingStatusPendingAccept),
+			Detail:   "Accept the transit gateway attachment in your AWS account (e.g., with the aws_ec2_transit_gateway_vpc_attachment_accepter resource).",
+		})
+	}
+	return diags
+}
+
+func executeTransitGatewayAttachmentRead(ctx context.Context, c *Client, environmentId, transitGatewayAttachmentId string) (networkingV1TransitGatewayAttachment, *http.Response, error) {
+	var transitGatewayAttachment networkingV1TransitGatewayAttachment
+	resp, err := c.legacyClient.Get(ctx, transitGatewayAttachmentPath(transitGatewayAttachmentId), url.Values{"environment": {environmentId}}, &transitGatewayAttachment)
+	return transitGatewayAttachment, resp, err
+}
+
+func transitGatewayAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Transit Gateway Attachment read for %s", d.Id())
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	_, err = readTransitGatewayAttachmentAndSetAttributes(ctx, d, meta, environmentId, d.Id())
+
+	return createDiagnosticsWithDetails(err)
+}
+
+func readTransitGatewayAttachmentAndSetAttributes(ctx context.Context, d *schema.ResourceData, meta interface{}, environmentId, transitGatewayAttachmentId string) ([]*schema.ResourceData, error) {
+	c := meta.(*Client)
+
+	transitGatewayAttachment, resp, err := executeTransitGatewayAttachmentRead(ctx, c, environmentId, transitGatewayAttachmentId)
+	if err != nil {
+		log.Printf("[WARN] Transit Gateway Attachment get failed for id %s, %v, %s", transitGatewayAttachmentId, resp, err)
+
+		// https://learn.hashicorp.com/tutorials/terraform/provider-setup
+		isResourceNotFound := HasStatusNotFound(resp)
+		if isResourceNotFound && !d.IsNewResource() {
+			log.Printf("[WARN] Transit Gateway Attachment with id=%s is not found", transitGatewayAttachmentId)
+			// If the resource isn't available, Terraform destroys the resource in state.
+			d.SetId("")
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if err := d.Set(paramDisplayName, transitGatewayAttachment.Spec.DisplayName); err != nil {
+		return nil, err
+	}
+	if cloud := transitGatewayAttachment.Spec.Cloud; cloud != nil {
+		if err := d.Set(paramAws, []interface{}{map[string]interface{}{
+			paramRamShareArn:                cloud.RamShareArn,
+			paramTransitGatewayId:           cloud.TransitGatewayId,
+			paramRoutes:                     cloud.Routes,
+			paramTransitGatewayAttachmentId: transitGatewayAttachment.Status.Cloud.TransitGatewayAttachmentId,
+		}}); err != nil {
+			return nil, err
+		}
+	}
+	if transitGatewayAttachment.Spec.Network != nil {
+		if err := setNetworkId(transitGatewayAttachment.Spec.Network.Id, d); err != nil {
+			return nil, err
+		}
+	}
+	if err := setEnvironmentId(environmentId, d); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func transitGatewayAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange(paramDisplayName) {
+		c := meta.(*Client)
+
+		environmentId, err := validEnvironmentId(d)
+		if err != nil {
+			return createDiagnosticsWithDetails(err)
+		}
+
+		resp, err := executeNetworkingDisplayNameUpdate(ctx, c, transitGatewayAttachmentPath(d.Id()), environmentId, d.Get(paramDisplayName).(string))
+		if err != nil {
+			log.Printf("[ERROR] Transit Gateway Attachment update failed for id %s, %v, %s", d.Id(), resp, err)
+			return createDiagnosticsWithDetails(err)
+		}
+	}
+
+	return transitGatewayAttachmentRead(ctx, d, meta)
+}
+
+func transitGatewayAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Transit Gateway Attachment delete for %s", d.Id())
+	c := meta.(*Client)
+
+	environmentId, err := validEnvironmentId(d)
+	if err != nil {
+		return createDiagnosticsWithDetails(err)
+	}
+
+	resp, err := c.legacyClient.Delete(ctx, transitGatewayAttachmentPath(d.Id()), url.Values{"environment": {environmentId}}, nil, nil)
+	if HasStatusNotFound(resp) {
+		log.Printf("[INFO] Transit Gateway Attachment %s is already deleted", d.Id())
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error deleting Transit Gateway Attachment (%s), err: %s", d.Id(), err)
+	}
+
+	if err := waitForTransitGatewayAttachmentToBeDeleted(ctx, c, environmentId, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for Transit Gateway Attachment (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Transit Gateway Attachment %s was deleted successfully", d.Id())
+
+	return nil
+}
+
+func transitGatewayAttachmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	envIDAndTransitGatewayAttachmentID := d.Id()
+	parts := strings.Split(envIDAndTransitGatewayAttachmentID, "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format for transit gateway attachment import: expected '<env ID>/<tgwa ID>'")
+	}
+
+	environmentId := parts[0]
+	transitGatewayAttachmentId := parts[1]
+	d.SetId(transitGatewayAttachmentId)
+	log.Printf("[INFO] Transit Gateway Attachment import for %s", transitGatewayAttachmentId)
+
+	return readTransitGatewayAttachmentAndSetAttributes(ctx, d, meta, environmentId, transitGatewayAttachmentId)
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitGatewayAttachmentProvisionStatus(t *testing.T) {
+	phase := "PROVISIONING"
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/transit-gateway-attachments/tgwa-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "tgwa-abc123", "status": {"phase": "` + phase + `", "error_code": "RAM_SHARE_NOT_FOUND", "error_message": "the RAM share is not accessible", "cloud": {"kind": "AwsTransitGatewayAttachmentStatus", "transit_gateway_attachment_id": "tgw-attach-abc123"}}}`))
+	})
+	refresh := transitGatewayAttachmentProvisionStatus(context.Background(), c, "env-abc123", "tgwa-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	phase = networkingStatusPendingAccept
+	transitGatewayAttachment, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+	require.Equal(t, "tgw-attach-abc123", transitGatewayAttachment.(networkingV1TransitGatewayAttachment).Status.Cloud.TransitGatewayAttachmentId)
+
+	phase = networkingStatusReady
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+
+	phase = stateFailed
+	_, state, err = refresh()
+	require.EqualError(t, err, "[ERROR] Transit Gateway Attachment provisioning has failed: the RAM share is not accessible (RAM_SHARE_NOT_FOUND)")
+	require.Equal(t, stateFailed, state)
+}
+
+func TestTransitGatewayAttachmentDeleteStatus(t *testing.T) {
+	deleted := false
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "tgwa-abc123", "status": {"phase": "DEPROVISIONING"}}`))
+	})
+	refresh := transitGatewayAttachmentDeleteStatus(context.Background(), c, "env-abc123", "tgwa-abc123")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateInProgress, state)
+
+	deleted = true
+	_, state, err = refresh()
+	require.NoError(t, err)
+	require.Equal(t, stateDone, state)
+}
+
+func TestExtractTransitGatewayAttachmentCloud(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, transitGatewayAttachmentResource().Schema, map[string]interface{}{
+		paramAws: []interface{}{map[string]interface{}{
+			paramRamShareArn:      "arn:aws:ram:us-east-2:012345678901:resource-share/abcdef01-2345-6789-abcd-ef0123456789",
+			paramTransitGatewayId: "tgw-abcdef0123456789a",
+			paramRoutes:           []interface{}{"10.0.0.0/8", "172.16.0.0/12"},
+		}},
+	})
+
+	require.Equal(t, &networkingV1TransitGatewayAttachmentCloud{
+		Kind:             transitGatewayAttachmentKindAws,
+		RamShareArn:      "arn:aws:ram:us-east-2:012345678901:resource-share/abcdef01-2345-6789-abcd-ef0123456789",
+		TransitGatewayId: "tgw-abcdef0123456789a",
+		Routes:           []string{"10.0.0.0/8", "172.16.0.0/12"},
+	}, extractTransitGatewayAttachmentCloud(d))
+}
+
+const testTransitGatewayAttachmentResponse = `{"id": "tgwa-abc123", "spec": {"display_name": "prod", "cloud": {"kind": "AwsTransitGatewayAttachment", "ram_share_arn": "arn:aws:ram:us-east-2:012345678901:resource-share/abcdef01-2345-6789-abcd-ef0123456789", "transit_gateway_id": "tgw-abcdef0123456789a", "routes": ["10.0.0.0/8"]}, "environment": {"id": "env-abc123"}, "network": {"id": "n-abc123"}}, "status": {"phase": "PENDING_ACCEPT", "cloud": {"kind": "AwsTransitGatewayAttachmentStatus", "transit_gateway_attachment_id": "tgw-attach-abc123"}}}`
+
+func testTransitGatewayAttachmentResourceData(t *testing.T) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, transitGatewayAttachmentResource().Schema, map[string]interface{}{
+		paramDisplayName: "prod",
+		paramAws: []interface{}{map[string]interface{}{
+			paramRamShareArn:      "arn:aws:ram:us-east-2:012345678901:resource-share/abcdef01-2345-6789-abcd-ef0123456789",
+			paramTransitGatewayId: "tgw-abcdef0123456789a",
+			paramRoutes:           []interface{}{"10.0.0.0/8"},
+		}},
+		paramEnvironment: []interface{}{map[string]interface{}{paramId: "env-abc123"}},
+		paramNetwork:     []interface{}{map[string]interface{}{paramId: "n-abc123"}},
+	})
+}
+
+func TestTransitGatewayAttachmentCreate(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/networking/v1/transit-gateway-attachments":
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"spec": {"display_name": "prod", "cloud": {"kind": "AwsTransitGatewayAttachment", "ram_share_arn": "arn:aws:ram:us-east-2:012345678901:resource-share/abcdef01-2345-6789-abcd-ef0123456789", "transit_gateway_id": "tgw-abcdef0123456789a", "routes": ["10.0.0.0/8"]}, "environment": {"id": "env-abc123"}, "network": {"id": "n-abc123"}}}`, string(body))
+			_, _ = w.Write([]byte(`{"id": "tgwa-abc123", "status": {"phase": "PROVISIONING"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/networking/v1/transit-gateway-attachments/tgwa-abc123":
+			require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+			_, _ = w.Write([]byte(testTransitGatewayAttachmentResponse))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	d := testTransitGatewayAttachmentResourceData(t)
+
+	diags := transitGatewayAttachmentCreate(context.Background(), d, c)
+	require.False(t, diags.HasError())
+	require.Equal(t, "tgwa-abc123", d.Id())
+	require.Equal(t, "prod", d.Get(paramDisplayName))
+	require.Equal(t, "tgw-attach-abc123", d.Get("aws.0.transit_gateway_attachment_id"))
+	require.Equal(t, "n-abc123", d.Get("network.0.id"))
+
+	// An attachment that still has to be accepted in AWS is reported as a warning
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Equal(t, "Transit Gateway Attachment (tgwa-abc123) is PENDING_ACCEPT", diags[0].Summary)
+}
+
+func TestTransitGatewayAttachmentUpdateDisplayName(t *testing.T) {
+	patched := false
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/networking/v1/transit-gateway-attachments/tgwa-abc123", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPatch {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"spec": {"display_name": "prod", "environment": {"id": "env-abc123"}}}`, string(body))
+			patched = true
+			_, _ = w.Write([]byte(`{"id": "tgwa-abc123"}`))
+			return
+		}
+		require.Equal(t, http.MethodGet, r.Method)
+		_, _ = w.Write([]byte(testTransitGatewayAttachmentResponse))
+	})
+	d := testTransitGatewayAttachmentResourceData(t)
+	d.SetId("tgwa-abc123")
+
+	require.Empty(t, transitGatewayAttachmentUpdate(context.Background(), d, c))
+	require.True(t, patched)
+	require.Equal(t, "prod", d.Get(paramDisplayName))
+}
+
+func TestTransitGatewayAttachmentReadOfDeletedTransitGatewayAttachment(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/networking/v1/transit-gateway-attachments/tgwa-abc123", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	d := testTransitGatewayAttachmentResourceData(t)
+	d.SetId("tgwa-abc123")
+
+	require.Empty(t, transitGatewayAttachmentRead(context.Background(), d, c))
+	require.Empty(t, d.Id())
+}
+
+func TestTransitGatewayAttachmentDeleteOfDeletedTransitGatewayAttachment(t *testing.T) {
+	c := newTestLegacyClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/networking/v1/transit-gateway-attachments/tgwa-abc123", r.URL.Path)
+		require.Equal(t, "env-abc123", r.URL.Query().Get("environment"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+	d := testTransitGatewayAttachmentResourceData(t)
+	d.SetId("tgwa-abc123")
+
+	require.False(t, transitGatewayAttachmentDelete(context.Background(), d, c).HasError())
+}
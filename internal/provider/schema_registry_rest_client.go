@@ -0,0 +1,259 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	schemaFormatAvro     = "AVRO"
+	schemaFormatProtobuf = "PROTOBUF"
+	schemaFormatJson     = "JSON"
+
+	latestSchemaVersion = "latest"
+
+	compatibilityLevelBackward = "BACKWARD"
+
+	subjectModeReadWrite = "READWRITE"
+)
+
+var acceptedSchemaFormats = []string{schemaFormatAvro, schemaFormatProtobuf, schemaFormatJson}
+
+var acceptedCompatibilityLevels = []string{compatibilityLevelBackward, "BACKWARD_TRANSITIVE", "FORWARD", "FORWARD_TRANSITIVE",
+	"FULL", "FULL_TRANSITIVE", "NONE"}
+
+var acceptedSubjectModes = []string{subjectModeReadWrite, "READONLY", "IMPORT"}
+
+// SchemaRegistryRestClient talks to the data plane (REST API) of a Schema Registry cluster
+// using a Schema Registry API key, the same way KafkaRestClient talks to a Kafka cluster.
+type SchemaRegistryRestClient struct {
+	client       *LegacyClient
+	httpEndpoint string
+	apiKey       string
+	apiSecret    string
+}
+
+type SchemaRegistryRestClientFactory struct {
+	userAgent string
+}
+
+func (f SchemaRegistryRestClientFactory) CreateSchemaRegistryRestClient(httpEndpoint, apiKey, apiSecret string) *SchemaRegistryRestClient {
+	return &SchemaRegistryRestClient{
+		client:       NewLegacyClient(httpEndpoint, f.userAgent, apiKey, apiSecret),
+		httpEndpoint: httpEndpoint,
+		apiKey:       apiKey,
+		apiSecret:    apiSecret,
+	}
+}
+
+type schemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type registerSchemaRequest struct {
+	Schema     string            `json:"schema"`
+	SchemaType string            `json:"schemaType,omitempty"`
+	References []schemaReference `json:"references,omitempty"`
+}
+
+type subjectVersion struct {
+	Subject    string            `json:"subject"`
+	Id         int               `json:"id"`
+	Version    int               `json:"version"`
+	SchemaType string            `json:"schemaType"`
+	Schema     string            `json:"schema"`
+	References []schemaReference `json:"references"`
+}
+
+// format returns the format of the schema, the REST API omits schemaType for Avro schemas.
+func (v subjectVersion) format() string {
+	if v.SchemaType == "" {
+		return schemaFormatAvro
+	}
+	return v.SchemaType
+}
+
+func newRegisterSchemaRequest(format, schema string, references []schemaReference) registerSchemaRequest {
+	request := registerSchemaRequest{
+		Schema:     schema,
+		References: references,
+	}
+	// Avro is the default format, so schemaType is only sent for Protobuf and JSON schemas
+	if format != schemaFormatAvro {
+		request.SchemaType = format
+	}
+	return request
+}
+
+func subjectPath(subjectName string) string {
+	return fmt.Sprintf("/subjects/%s", url.PathEscape(subjectName))
+}
+
+// registerSchema registers the schema under the subject (or returns the existing version when the subject
+// already contains an identical schema) and returns the registered version.
+func (c *SchemaRegistryRestClient) registerSchema(ctx context.Context, subjectName string, request registerSchemaRequest) (subjectVersion, *http.Response, error) {
+	var registered struct {
+		Id int `json:"id"`
+	}
+	resp, err := c.client.Post(ctx, subjectPath(subjectName)+"/versions", nil, request, &registered)
+	if err != nil {
+		return subjectVersion{}, resp, err
+	}
+	return c.lookupSchema(ctx, subjectName, request)
+}
+
+// lookupSchema returns the version of the subject that contains the schema.
+func (c *SchemaRegistryRestClient) lookupSchema(ctx context.Context, subjectName string, request registerSchemaRequest) (subjectVersion, *http.Response, error) {
+	var version subjectVersion
+	resp, err := c.client.Post(ctx, subjectPath(subjectName), nil, request, &version)
+	return version, resp, err
+}
+
+// readSubjectVersion returns the given version (a number or "latest") of the subject.
+func (c *SchemaRegistryRestClient) readSubjectVersion(ctx context.Context, subjectName, version string) (subjectVersion, *http.Response, error) {
+	var result subjectVersion
+	resp, err := c.client.Get(ctx, fmt.Sprintf("%s/versions/%s", subjectPath(subjectName), url.PathEscape(version)), nil, &result)
+	return result, resp, err
+}
+
+// readSchemaVersion returns the schema with the given ID together with the version of the subject it's registered as.
+// The response has http.StatusNotFound status if the schema doesn't exist or isn't a (non-deleted) version of the subject.
+func (c *SchemaRegistryRestClient) readSchemaVersion(ctx context.Context, subjectName string, schemaId int) (subjectVersion, *http.Response, error) {
+	var result subjectVersion
+	resp, err := c.client.Get(ctx, fmt.Sprintf("/schemas/ids/%d", schemaId), nil, &result)
+	if err != nil {
+		return subjectVersion{}, resp, err
+	}
+
+	var versions []struct {
+		Subject string `json:"subject"`
+		Version int    `json:"version"`
+	}
+	resp, err = c.client.Get(ctx, fmt.Sprintf("/schemas/ids/%d/versions", schemaId), nil, &versions)
+	if err != nil {
+		return subjectVersion{}, resp, err
+	}
+	for _, version := range versions {
+		if version.Subject == subjectName {
+			result.Subject = version.Subject
+			result.Id = schemaId
+			result.Version = version.Version
+			return result, resp, nil
+		}
+	}
+	return subjectVersion{}, &http.Response{StatusCode: http.StatusNotFound}, fmt.Errorf("the schema %d is not registered under subject %s", schemaId, subjectName)
+}
+
+// deleteSubject soft-deletes all versions of the subject and, if permanent is set, hard-deletes them afterwards.
+func (c *SchemaRegistryRestClient) deleteSubject(ctx context.Context, subjectName string, permanent bool) (*http.Response, error) {
+	resp, err := c.client.Delete(ctx, subjectPath(subjectName), nil, nil, nil)
+	if err != nil || !permanent {
+		return resp, err
+	}
+	return c.client.Delete(ctx, subjectPath(subjectName), url.Values{"permanent": {"true"}}, nil, nil)
+}
+
+// configPath returns the path of the subject's config, or of the registry-wide config if subjectName is empty.
+func configPath(subjectName string) string {
+	if subjectName == "" {
+		return "/config"
+	}
+	return fmt.Sprintf("/config/%s", url.PathEscape(subjectName))
+}
+
+// readCompatibilityLevel returns the compatibility level of the subject, or the registry-wide one if subjectName is empty.
+// It returns 404 Not Found for a subject without its own compatibility level.
+func (c *SchemaRegistryRestClient) readCompatibilityLevel(ctx context.Context, subjectName string) (string, *http.Response, error) {
+	var config struct {
+		CompatibilityLevel string `json:"compatibilityLevel"`
+	}
+	resp, err := c.client.Get(ctx, configPath(subjectName), nil, &config)
+	return config.CompatibilityLevel, resp, err
+}
+
+func (c *SchemaRegistryRestClient) updateCompatibilityLevel(ctx context.Context, subjectName, compatibilityLevel string) (*http.Response, error) {
+	request := struct {
+		Compatibility string `json:"compatibility"`
+	}{compatibilityLevel}
+	return c.client.Put(ctx, configPath(subjectName), nil, request, nil)
+}
+
+// deleteCompatibilityLevel makes the subject fall back to the registry-wide compatibility level.
+func (c *SchemaRegistryRestClient) deleteCompatibilityLevel(ctx context.Context, subjectName string) (*http.Response, error) {
+	return c.client.Delete(ctx, configPath(subjectName), nil, nil, nil)
+}
+
+// modePath returns the path of the subject's mode, or of the registry-wide mode if subjectName is empty.
+func modePath(subjectName string) string {
+	if subjectName == "" {
+		return "/mode"
+	}
+	return fmt.Sprintf("/mode/%s", url.PathEscape(subjectName))
+}
+
+// readMode returns the mode of the subject, or the registry-wide one if subjectName is empty.
+func (c *SchemaRegistryRestClient) readMode(ctx context.Context, subjectName string) (string, *http.Response, error) {
+	var mode struct {
+		Mode string `json:"mode"`
+	}
+	resp, err := c.client.Get(ctx, modePath(subjectName), nil, &mode)
+	return mode.Mode, resp, err
+}
+
+// updateMode sets the mode of the subject, or the registry-wide one if subjectName is empty.
+// force is required to switch to IMPORT mode while the registry (or the subject) already contains schemas.
+func (c *SchemaRegistryRestClient) updateMode(ctx context.Context, subjectName, mode string, force bool) (*http.Response, error) {
+	request := struct {
+		Mode string `json:"mode"`
+	}{mode}
+	var query url.Values
+	if force {
+		query = url.Values{"force": {"true"}}
+	}
+	return c.client.Put(ctx, modePath(subjectName), query, request, nil)
+}
+
+// readEffectiveCompatibilityLevel returns the compatibility level that applies to the subject:
+// its own one or, if it has none, the registry-wide one.
+func (c *SchemaRegistryRestClient) readEffectiveCompatibilityLevel(ctx context.Context, subjectName string) (string, *http.Response, error) {
+	compatibilityLevel, resp, err := c.readCompatibilityLevel(ctx, subjectName)
+	if HasStatusNotFound(resp) {
+		return c.readCompatibilityLevel(ctx, "")
+	}
+	return compatibilityLevel, resp, err
+}
+
+type compatibilityCheckResult struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+// testCompatibility checks the schema against the latest version of the subject or, if allVersions is set,
+// against all of its versions. The messages explain why the schema is incompatible.
+func (c *SchemaRegistryRestClient) testCompatibility(ctx context.Context, subjectName string, request registerSchemaRequest, allVersions bool) (compatibilityCheckResult, *http.Response, error) {
+	path := fmt.Sprintf("/compatibility%s/versions", subjectPath(subjectName))
+	if !allVersions {
+		path = fmt.Sprintf("%s/%s", path, latestSchemaVersion)
+	}
+	var result compatibilityCheckResult
+	resp, err := c.client.Post(ctx, path, url.Values{"verbose": {"true"}}, request, &result)
+	return result, resp, err
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSchemaRegistrySubjectName = "orders-value"
+
+func TestSchemaRegistryRestClientRegisterSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		var request registerSchemaRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, schemaFormatProtobuf, request.SchemaType)
+		require.Equal(t, []schemaReference{{Name: "common.proto", Subject: "common", Version: 2}}, request.References)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subjects/orders-value/versions":
+			_, _ = w.Write([]byte(`{"id": 100001}`))
+		case "/subjects/orders-value":
+			_, _ = w.Write([]byte(`{"subject": "orders-value", "id": 100001, "version": 3, "schemaType": "PROTOBUF", "schema": "syntax = \"proto3\";"}`))
+		default:
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}.CreateSchemaRegistryRestClient(server.URL, "foo", "bar")
+	request := newRegisterSchemaRequest(schemaFormatProtobuf, `syntax = "proto3";`, []schemaReference{{Name: "common.proto", Subject: "common", Version: 2}})
+	registered, _, err := client.registerSchema(context.Background(), testSchemaRegistrySubjectName, request)
+	require.NoError(t, err)
+	require.Equal(t, 100001, registered.Id)
+	require.Equal(t, 3, registered.Version)
+	require.Equal(t, schemaFormatProtobuf, registered.format())
+}
+
+func TestSchemaRegistryRestClientDeleteSubject(t *testing.T) {
+	var permanentFlags []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/subjects/orders-value", r.URL.Path)
+		permanentFlags = append(permanentFlags, r.URL.Query().Get("permanent"))
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	}))
+	defer server.Close()
+
+	client := SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}.CreateSchemaRegistryRestClient(server.URL, "foo", "bar")
+	_, err := client.deleteSubject(context.Background(), testSchemaRegistrySubjectName, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "true"}, permanentFlags)
+}
+
+func TestNewRegisterSchemaRequestOmitsAvroSchemaType(t *testing.T) {
+	request := newRegisterSchemaRequest(schemaFormatAvro, `{"type": "string"}`, nil)
+	require.Equal(t, "", request.SchemaType)
+	require.Equal(t, schemaFormatAvro, subjectVersion{}.format())
+}
+
+func TestSchemaRegistryRestClientCompatibilityLevel(t *testing.T) {
+	var requestedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			var request map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			require.Equal(t, "NONE", request["compatibility"])
+			_, _ = w.Write([]byte(`{"compatibility": "NONE"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"compatibilityLevel": "FULL_TRANSITIVE"}`))
+	}))
+	defer server.Close()
+
+	client := SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}.CreateSchemaRegistryRestClient(server.URL, "foo", "bar")
+	_, err := client.updateCompatibilityLevel(context.Background(), "", "NONE")
+	require.NoError(t, err)
+	compatibilityLevel, _, err := client.readCompatibilityLevel(context.Background(), testSchemaRegistrySubjectName)
+	require.NoError(t, err)
+	require.Equal(t, "FULL_TRANSITIVE", compatibilityLevel)
+	require.Equal(t, []string{"PUT /config", "GET /config/orders-value"}, requestedPaths)
+}
+
+func TestSchemaRegistryRestClientUpdateModeWithForce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/mode", r.URL.Path)
+		require.Equal(t, "true", r.URL.Query().Get("force"))
+		var request map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, "IMPORT", request["mode"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"mode": "IMPORT"}`))
+	}))
+	defer server.Close()
+
+	client := SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}.CreateSchemaRegistryRestClient(server.URL, "foo", "bar")
+	_, err := client.updateMode(context.Background(), "", "IMPORT", true)
+	require.NoError(t, err)
+}
+
+func TestSchemaRegistryRestClientReadEffectiveCompatibilityLevel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/config/orders-value" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40408, "message": "Subject 'orders-value' does not have subject-level compatibility configured"}`))
+			return
+		}
+		require.Equal(t, "/config", r.URL.Path)
+		_, _ = w.Write([]byte(`{"compatibilityLevel": "BACKWARD"}`))
+	}))
+	defer server.Close()
+
+	client := SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}.CreateSchemaRegistryRestClient(server.URL, "foo", "bar")
+	compatibilityLevel, _, err := client.readEffectiveCompatibilityLevel(context.Background(), testSchemaRegistrySubjectName)
+	require.NoError(t, err)
+	require.Equal(t, compatibilityLevelBackward, compatibilityLevel)
+}
+
+func TestSchemaRegistryRestClientTestCompatibility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/compatibility/subjects/orders-value/versions", r.URL.Path)
+		require.Equal(t, "true", r.URL.Query().Get("verbose"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"is_compatible": false, "messages": ["READER_FIELD_MISSING_DEFAULT_VALUE"]}`))
+	}))
+	defer server.Close()
+
+	client := SchemaRegistryRestClientFactory{userAgent: "test-user-agent"}.CreateSchemaRegistryRestClient(server.URL, "foo", "bar")
+	request := newRegisterSchemaRequest(schemaFormatAvro, `{"type": "string"}`, nil)
+	result, _, err := client.testCompatibility(context.Background(), testSchemaRegistrySubjectName, request, true)
+	require.NoError(t, err)
+	require.False(t, result.IsCompatible)
+	require.Equal(t, []string{"READER_FIELD_MISSING_DEFAULT_VALUE"}, result.Messages)
+}
@@ -410,6 +410,16 @@ func convertToStringStringMap(data map[string]interface{}) map[string]string {
 	return stringMap
 }
 
+func convertToStringSlice(items []interface{}) []string {
+	stringItems := make([]string, len(items))
+
+	for i, item := range items {
+		stringItems[i] = item.(string)
+	}
+
+	return stringItems
+}
+
 func ptr(s string) *string {
 	return &s
 }
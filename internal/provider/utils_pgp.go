@@ -0,0 +1,121 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const (
+	keybasePgpKeyPrefix = "keybase:"
+	keybaseLookupUrl    = "https://keybase.io/_/api/1.0/user/lookup.json"
+)
+
+// retrievePgpKey parses a PGP public key that is either base64-encoded (e.g., `gpg --export <key ID> | base64`)
+// or a reference to a Keybase user in the `keybase:<username>` format, and checks that it can encrypt.
+func retrievePgpKey(ctx context.Context, pgpKey string) (*openpgp.Entity, error) {
+	var entity *openpgp.Entity
+	if strings.HasPrefix(pgpKey, keybasePgpKeyPrefix) {
+		username := strings.TrimPrefix(pgpKey, keybasePgpKeyPrefix)
+		armoredPublicKey, err := fetchKeybasePublicKey(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredPublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing PGP key of Keybase user %s: %s", username, err)
+		}
+		entity = entities[0]
+	} else {
+		publicKey, err := base64.StdEncoding.DecodeString(pgpKey)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding PGP key, it must be base64-encoded or of the form '%s<username>': %s", keybasePgpKeyPrefix, err)
+		}
+		entities, err := openpgp.ReadKeyRing(bytes.NewReader(publicKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing PGP key: %s", err)
+		}
+		entity = entities[0]
+	}
+
+	if _, ok := entity.EncryptionKey(time.Now()); !ok {
+		return nil, fmt.Errorf("the PGP key %s has no valid encryption key", hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]))
+	}
+	return entity, nil
+}
+
+func fetchKeybasePublicKey(ctx context.Context, username string) (string, error) {
+	type keybaseLookupResponse struct {
+		Them []struct {
+			PublicKeys struct {
+				Primary struct {
+					Bundle string `json:"bundle"`
+				} `json:"primary"`
+			} `json:"public_keys"`
+		} `json:"them"`
+	}
+
+	query := url.Values{"usernames": {username}, "fields": {"public_keys"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", keybaseLookupUrl, query.Encode()), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := createRetryableHttpClientWithExponentialBackoff().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error fetching PGP key of Keybase user %s: %s", username, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error fetching PGP key of Keybase user %s: %s", username, resp.Status)
+	}
+
+	var lookup keybaseLookupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&lookup); err != nil {
+		return "", fmt.Errorf("error decoding PGP key of Keybase user %s: %s", username, err)
+	}
+	if len(lookup.Them) == 0 || lookup.Them[0].PublicKeys.Primary.Bundle == "" {
+		return "", fmt.Errorf("could not find PGP key of Keybase user %s", username)
+	}
+	return lookup.Them[0].PublicKeys.Primary.Bundle, nil
+}
+
+// encryptWithPgpKey encrypts plaintext for the PGP entity and returns the base64-encoded message
+// (decrypt it with `base64 --decode | gpg --decrypt`) together with the hex-encoded fingerprint of the key.
+func encryptWithPgpKey(entity *openpgp.Entity, plaintext string) (string, string, error) {
+	var ciphertext bytes.Buffer
+	plaintextWriter, err := openpgp.Encrypt(&ciphertext, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("error encrypting with PGP key: %s", err)
+	}
+	if _, err := plaintextWriter.Write([]byte(plaintext)); err != nil {
+		return "", "", fmt.Errorf("error encrypting with PGP key: %s", err)
+	}
+	if err := plaintextWriter.Close(); err != nil {
+		return "", "", fmt.Errorf("error encrypting with PGP key: %s", err)
+	}
+	fingerprint := hex.EncodeToString(entity.PrimaryKey.Fingerprint[:])
+	return base64.StdEncoding.EncodeToString(ciphertext.Bytes()), fingerprint, nil
+}
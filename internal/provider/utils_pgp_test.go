@@ -0,0 +1,55 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptWithPgpKey(t *testing.T) {
+	entity, err := openpgp.NewEntity("Test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	var publicKey bytes.Buffer
+	require.NoError(t, entity.Serialize(&publicKey))
+
+	pgpKey := base64.StdEncoding.EncodeToString(publicKey.Bytes())
+	publicEntity, err := retrievePgpKey(context.Background(), pgpKey)
+	require.NoError(t, err)
+
+	encryptedSecret, fingerprint, err := encryptWithPgpKey(publicEntity, "my-api-secret")
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]), fingerprint)
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedSecret)
+	require.NoError(t, err)
+	message, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), openpgp.EntityList{entity}, nil, nil)
+	require.NoError(t, err)
+	plaintext, err := ioutil.ReadAll(message.UnverifiedBody)
+	require.NoError(t, err)
+	require.Equal(t, "my-api-secret", string(plaintext))
+}
+
+func TestRetrievePgpKeyInvalid(t *testing.T) {
+	_, err := retrievePgpKey(context.Background(), "not base64!")
+	require.Error(t, err)
+}
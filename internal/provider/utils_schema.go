@@ -0,0 +1,84 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/linkedin/goavro/v2"
+)
+
+// validateSchemaSyntax catches syntax errors in a schema before it's sent to Schema Registry.
+// It doesn't resolve references, Schema Registry does that.
+func validateSchemaSyntax(format, schema string, references []schemaReference) error {
+	if strings.TrimSpace(schema) == "" {
+		return fmt.Errorf("the schema is empty")
+	}
+	switch format {
+	case schemaFormatAvro:
+		return validateAvroSchemaSyntax(schema, len(references) > 0)
+	case schemaFormatJson:
+		return validateJsonSchemaSyntax(schema)
+	case schemaFormatProtobuf:
+		return validateProtobufSchemaSyntax(schema)
+	}
+	return fmt.Errorf("unknown schema format %q", format)
+}
+
+// validateAvroSchemaSyntax parses the schema with goavro, which also rejects invalid names, fields and defaults.
+// The named types of a schema with references are registered under other subjects, so it's only parsed as JSON.
+func validateAvroSchemaSyntax(schema string, hasReferences bool) error {
+	if hasReferences {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+			return fmt.Errorf("invalid Avro schema: %s", err)
+		}
+		return nil
+	}
+	if _, err := goavro.NewCodec(schema); err != nil {
+		return fmt.Errorf("invalid Avro schema: %s", err)
+	}
+	return nil
+}
+
+func validateJsonSchemaSyntax(schema string) error {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return fmt.Errorf("invalid JSON schema: %s", err)
+	}
+	switch parsed.(type) {
+	case map[string]interface{}, bool:
+		return nil
+	}
+	return fmt.Errorf("invalid JSON schema: expected a JSON object or boolean")
+}
+
+// protobufSchemaFileName is the name the schema is parsed under, it only shows up in error messages.
+const protobufSchemaFileName = "schema.proto"
+
+// validateProtobufSchemaSyntax parses the schema as a .proto file without resolving its imports.
+func validateProtobufSchemaSyntax(schema string) error {
+	parser := protoparse.Parser{
+		Accessor:              protoparse.FileContentsFromMap(map[string]string{protobufSchemaFileName: schema}),
+		ValidateUnlinkedFiles: true,
+	}
+	if _, err := parser.ParseFilesButDoNotLink(protobufSchemaFileName); err != nil {
+		return fmt.Errorf("invalid Protobuf schema: %s", err)
+	}
+	return nil
+}
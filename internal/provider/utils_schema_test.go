@@ -0,0 +1,76 @@
+// Copyright 2021 Confluent Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchemaSyntax(t *testing.T) {
+	validSchemas := map[string][]string{
+		schemaFormatAvro: {
+			`{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`,
+			`"string"`,
+			`["null", "string"]`,
+		},
+		schemaFormatJson: {
+			`{"type": "object", "properties": {"id": {"type": "string"}}}`,
+			`true`,
+		},
+		schemaFormatProtobuf: {
+			"syntax = \"proto3\";\n// A comment with an unbalanced {\nmessage Order {\n  /* } */\n  string id = 1 [json_name = \"ID)\"];\n}\n",
+			// Imports aren't resolved, Schema Registry resolves them from the schema references
+			"syntax = \"proto3\";\nimport \"customer.proto\";\nmessage Order {\n  Customer customer = 1;\n}\n",
+		},
+	}
+	for format, schemas := range validSchemas {
+		for _, schema := range schemas {
+			require.NoError(t, validateSchemaSyntax(format, schema, nil), schema)
+		}
+	}
+
+	invalidSchemas := map[string]struct {
+		format        string
+		expectedError string
+	}{
+		`{"type": "record", "name": "Order",}`:                                             {schemaFormatAvro, "invalid Avro schema: cannot unmarshal schema JSON: invalid character '}' looking for beginning of object key string"},
+		`{"name": "Order"}`:                                                                {schemaFormatAvro, "invalid Avro schema: missing type: map[name:Order]"},
+		`{"type": "record", "name": "Order"}`:                                              {schemaFormatAvro, `invalid Avro schema: Record "Order" ought to have fields key`},
+		`{"type": "record", "name": "1Order", "fields": []}`:                               {schemaFormatAvro, "invalid Avro schema: Record ought to have valid name: schema name ought to start with [A-Za-z_]: 1Order"},
+		`{"type": "record", "name": "Order", "fields": [{"type": "string"}]}`:              {schemaFormatAvro, `invalid Avro schema: Record "Order" field 1 ought to have valid name: map[type:string]`},
+		`{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "strin"}]}`: {schemaFormatAvro, `invalid Avro schema: Record "Order" field 1 ought to be valid Avro named type: unknown type name: "strin"`},
+		`"string"`:                               {schemaFormatJson, "invalid JSON schema: expected a JSON object or boolean"},
+		"message Order {\n  string id = 1;\n":    {schemaFormatProtobuf, "invalid Protobuf schema: schema.proto:2:17: syntax error: unexpected $end"},
+		"message Order { int32 = ; }":            {schemaFormatProtobuf, "invalid Protobuf schema: schema.proto:1:23: syntax error: unexpected '='"},
+		"syntax = \"proto3;\nmessage Order {}\n": {schemaFormatProtobuf, "invalid Protobuf schema: schema.proto:1:8: encountered end-of-line before end of string literal"},
+		"syntax = \"proto3\";\nmessage Order { string id = 1; string name = 1; }\n": {schemaFormatProtobuf, "invalid Protobuf schema: schema.proto:2:46: message Order: fields id and name both have the same tag 1"},
+		" ": {schemaFormatProtobuf, "the schema is empty"},
+	}
+	for schema, expected := range invalidSchemas {
+		require.EqualError(t, validateSchemaSyntax(expected.format, schema, nil), expected.expectedError, schema)
+	}
+}
+
+func TestValidateSchemaSyntaxWithReferences(t *testing.T) {
+	schema := `{"type": "record", "name": "Order", "fields": [{"name": "customer", "type": "com.example.Customer"}]}`
+	references := []schemaReference{{Name: "com.example.Customer", Subject: "customer-value", Version: 1}}
+
+	// The referenced named types are registered under other subjects, so they can't be resolved locally
+	require.EqualError(t, validateSchemaSyntax(schemaFormatAvro, schema, nil), `invalid Avro schema: Record "Order" field 1 ought to be valid Avro named type: unknown type name: "com.example.Customer"`)
+	require.NoError(t, validateSchemaSyntax(schemaFormatAvro, schema, references))
+	require.EqualError(t, validateSchemaSyntax(schemaFormatAvro, `{"type": "record",`, references), "invalid Avro schema: unexpected end of JSON input")
+}
@@ -139,3 +139,373 @@ func kafkaClusterProvisionStatus(ctx context.Context, c *Client, environmentId s
 		return cluster, stateDone, nil
 	}
 }
+
+func waitForSchemaRegistryToProvision(ctx context.Context, c *Client, environmentId, schemaRegistryId string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      schemaRegistryProvisionStatus(ctx, c, environmentId, schemaRegistryId),
+		Timeout:      1 * time.Hour,
+		Delay:        5 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for Schema Registry provisioning to become %s", stateDone)
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func schemaRegistryProvisionStatus(ctx context.Context, c *Client, environmentId string, schemaRegistryId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		cluster, resp, err := executeSchemaRegistryRead(ctx, c, environmentId, schemaRegistryId)
+		if err != nil {
+			log.Printf("[ERROR] Schema Registry get failed for id %s, %+v, %s", schemaRegistryId, resp, err)
+			return nil, stateUnknown, err
+		}
+
+		log.Printf("[DEBUG] Waiting for Schema Registry to be %s: current status %s", schemaRegistryStatusUp, cluster.Status)
+		if strings.ToUpper(cluster.Status) == schemaRegistryStatusUp {
+			return cluster, stateDone, nil
+		} else if strings.ToUpper(cluster.Status) == stateFailed {
+			return nil, stateFailed, fmt.Errorf("[ERROR] Schema Registry provisioning has failed")
+		}
+		return cluster, stateInProgress, nil
+	}
+}
+
+func waitForKsqlDbClusterToProvision(ctx context.Context, c *Client, environmentId, clusterId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      ksqlDbClusterProvisionStatus(ctx, c, environmentId, clusterId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for ksqlDB cluster provisioning to become %s", stateDone)
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func waitForKsqlDbClusterToBeDeleted(ctx context.Context, c *Client, environmentId, clusterId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      ksqlDbClusterDeleteStatus(ctx, c, environmentId, clusterId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for ksqlDB cluster to be deleted")
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func ksqlDbClusterProvisionStatus(ctx context.Context, c *Client, environmentId string, clusterId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		cluster, resp, err := executeKsqlDbClusterRead(ctx, c, environmentId, clusterId)
+		if err != nil {
+			log.Printf("[ERROR] ksqlDB cluster get failed for id %s, %+v, %s", clusterId, resp, err)
+			return nil, stateUnknown, err
+		}
+
+		log.Printf("[DEBUG] Waiting for ksqlDB cluster to be %s: current status %s", ksqlDbClusterStatusProvisioned, cluster.Status)
+		if strings.ToUpper(cluster.Status) == ksqlDbClusterStatusProvisioned {
+			return cluster, stateDone, nil
+		} else if strings.ToUpper(cluster.Status) == stateFailed {
+			return nil, stateFailed, fmt.Errorf("[ERROR] ksqlDB cluster provisioning has failed")
+		}
+		return cluster, stateInProgress, nil
+	}
+}
+
+func ksqlDbClusterDeleteStatus(ctx context.Context, c *Client, environmentId string, clusterId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		cluster, resp, err := executeKsqlDbClusterRead(ctx, c, environmentId, clusterId)
+		if err != nil {
+			// 404 means that the ksqlDB cluster has been deleted
+			if HasStatusNotFound(resp) {
+				// Result (the 1st argument) can't be nil
+				return 0, stateDone, nil
+			}
+			log.Printf("[ERROR] ksqlDB cluster get failed for id %s, %+v, %s", clusterId, resp, err)
+			return nil, stateUnknown, err
+		}
+		log.Printf("[DEBUG] Waiting for ksqlDB cluster to be deleted: current status %s", cluster.Status)
+		return cluster, stateInProgress, nil
+	}
+}
+
+func waitForNetworkToProvision(ctx context.Context, c *Client, environmentId, networkId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      networkProvisionStatus(ctx, c, environmentId, networkId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 1 * time.Minute,
+	}
+
+	log.Printf("[DEBUG] Waiting for Network provisioning to become %s", stateDone)
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func waitForNetworkToBeDeleted(ctx context.Context, c *Client, environmentId, networkId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      networkDeleteStatus(ctx, c, environmentId, networkId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 1 * time.Minute,
+	}
+
+	log.Printf("[DEBUG] Waiting for Network to be deleted")
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func networkProvisionStatus(ctx context.Context, c *Client, environmentId string, networkId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		network, resp, err := executeNetworkRead(ctx, c, environmentId, networkId)
+		if err != nil {
+			log.Printf("[ERROR] Network get failed for id %s, %+v, %s", networkId, resp, err)
+			return nil, stateUnknown, err
+		}
+
+		log.Printf("[DEBUG] Waiting for Network to be %s: current status %s", networkingStatusReady, network.Status.Phase)
+		if network.Status.Phase == networkingStatusReady {
+			return network, stateDone, nil
+		} else if network.Status.Phase == stateFailed {
+			return nil, stateFailed, fmt.Errorf("[ERROR] Network provisioning has failed: %s", network.Status.failureMessage())
+		}
+		return network, stateInProgress, nil
+	}
+}
+
+func networkDeleteStatus(ctx context.Context, c *Client, environmentId string, networkId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		network, resp, err := executeNetworkRead(ctx, c, environmentId, networkId)
+		if err != nil {
+			// 404 means that the Network has been deleted
+			if HasStatusNotFound(resp) {
+				// Result (the 1st argument) can't be nil
+				return 0, stateDone, nil
+			}
+			log.Printf("[ERROR] Network get failed for id %s, %+v, %s", networkId, resp, err)
+			return nil, stateUnknown, err
+		}
+		log.Printf("[DEBUG] Waiting for Network to be deleted: current status %s", network.Status.Phase)
+		return network, stateInProgress, nil
+	}
+}
+
+// waitForPeeringToProvision returns the phase the Peering settled in, either READY or PENDING_ACCEPT,
+// since a Peering can't become READY until it's accepted on the cloud provider side.
+func waitForPeeringToProvision(ctx context.Context, c *Client, environmentId, peeringId string, timeout time.Duration) (string, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      peeringProvisionStatus(ctx, c, environmentId, peeringId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for Peering provisioning to become %s", stateDone)
+	peering, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return peering.(networkingV1Peering).Status.Phase, nil
+}
+
+func waitForPeeringToBeDeleted(ctx context.Context, c *Client, environmentId, peeringId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      peeringDeleteStatus(ctx, c, environmentId, peeringId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for Peering to be deleted")
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func peeringProvisionStatus(ctx context.Context, c *Client, environmentId string, peeringId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		peering, resp, err := executePeeringRead(ctx, c, environmentId, peeringId)
+		if err != nil {
+			log.Printf("[ERROR] Peering get failed for id %s, %+v, %s", peeringId, resp, err)
+			return nil, stateUnknown, err
+		}
+
+		log.Printf("[DEBUG] Waiting for Peering to be %s: current status %s", networkingStatusReady, peering.Status.Phase)
+		switch peering.Status.Phase {
+		case networkingStatusReady, networkingStatusPendingAccept:
+			return peering, stateDone, nil
+		case stateFailed:
+			return nil, stateFailed, fmt.Errorf("[ERROR] Peering provisioning has failed: %s", peering.Status.failureMessage())
+		}
+		return peering, stateInProgress, nil
+	}
+}
+
+func peeringDeleteStatus(ctx context.Context, c *Client, environmentId string, peeringId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		peering, resp, err := executePeeringRead(ctx, c, environmentId, peeringId)
+		if err != nil {
+			// 404 means that the Peering has been deleted
+			if HasStatusNotFound(resp) {
+				// Result (the 1st argument) can't be nil
+				return 0, stateDone, nil
+			}
+			log.Printf("[ERROR] Peering get failed for id %s, %+v, %s", peeringId, resp, err)
+			return nil, stateUnknown, err
+		}
+		log.Printf("[DEBUG] Waiting for Peering to be deleted: current status %s", peering.Status.Phase)
+		return peering, stateInProgress, nil
+	}
+}
+
+func waitForPrivateLinkAccessToProvision(ctx context.Context, c *Client, environmentId, privateLinkAccessId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      privateLinkAccessProvisionStatus(ctx, c, environmentId, privateLinkAccessId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for private link access provisioning to become %s", stateDone)
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func privateLinkAccessProvisionStatus(ctx context.Context, c *Client, environmentId string, privateLinkAccessId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		privateLinkAccess, resp, err := executePrivateLinkAccessRead(ctx, c, environmentId, privateLinkAccessId)
+		if err != nil {
+			log.Printf("[ERROR] Private link access get failed for id %s, %+v, %s", privateLinkAccessId, resp, err)
+			return nil, stateUnknown, err
+		}
+
+		log.Printf("[DEBUG] Waiting for private link access to be %s: current status %s", networkingStatusReady, privateLinkAccess.Status.Phase)
+		if privateLinkAccess.Status.Phase == networkingStatusReady {
+			return privateLinkAccess, stateDone, nil
+		} else if privateLinkAccess.Status.Phase == stateFailed {
+			return nil, stateFailed, fmt.Errorf("[ERROR] Private link access provisioning has failed: %s", privateLinkAccess.Status.failureMessage())
+		}
+		return privateLinkAccess, stateInProgress, nil
+	}
+}
+
+func waitForPrivateLinkAccessToBeDeleted(ctx context.Context, c *Client, environmentId, privateLinkAccessId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      privateLinkAccessDeleteStatus(ctx, c, environmentId, privateLinkAccessId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for private link access to be deleted")
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func privateLinkAccessDeleteStatus(ctx context.Context, c *Client, environmentId string, privateLinkAccessId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		privateLinkAccess, resp, err := executePrivateLinkAccessRead(ctx, c, environmentId, privateLinkAccessId)
+		if err != nil {
+			// 404 means that the private link access has been deleted
+			if HasStatusNotFound(resp) {
+				// Result (the 1st argument) can't be nil
+				return 0, stateDone, nil
+			}
+			log.Printf("[ERROR] Private link access get failed for id %s, %+v, %s", privateLinkAccessId, resp, err)
+			return nil, stateUnknown, err
+		}
+		log.Printf("[DEBUG] Waiting for private link access to be deleted: current status %s", privateLinkAccess.Status.Phase)
+		return privateLinkAccess, stateInProgress, nil
+	}
+}
+
+// waitForTransitGatewayAttachmentToProvision returns the phase the Transit Gateway Attachment settled in,
+// either READY or PENDING_ACCEPT, since an attachment can't become READY until it's accepted in AWS.
+func waitForTransitGatewayAttachmentToProvision(ctx context.Context, c *Client, environmentId, transitGatewayAttachmentId string, timeout time.Duration) (string, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      transitGatewayAttachmentProvisionStatus(ctx, c, environmentId, transitGatewayAttachmentId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for Transit Gateway Attachment provisioning to become %s", stateDone)
+	transitGatewayAttachment, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return transitGatewayAttachment.(networkingV1TransitGatewayAttachment).Status.Phase, nil
+}
+
+func waitForTransitGatewayAttachmentToBeDeleted(ctx context.Context, c *Client, environmentId, transitGatewayAttachmentId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{stateInProgress},
+		Target:       []string{stateDone},
+		Refresh:      transitGatewayAttachmentDeleteStatus(ctx, c, environmentId, transitGatewayAttachmentId),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for Transit Gateway Attachment to be deleted")
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func transitGatewayAttachmentProvisionStatus(ctx context.Context, c *Client, environmentId string, transitGatewayAttachmentId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		transitGatewayAttachment, resp, err := executeTransitGatewayAttachmentRead(ctx, c, environmentId, transitGatewayAttachmentId)
+		if err != nil {
+			log.Printf("[ERROR] Transit Gateway Attachment get failed for id %s, %+v, %s", transitGatewayAttachmentId, resp, err)
+			return nil, stateUnknown, err
+		}
+
+		log.Printf("[DEBUG] Waiting for Transit Gateway Attachment to be %s: current status %s", networkingStatusReady, transitGatewayAttachment.Status.Phase)
+		switch transitGatewayAttachment.Status.Phase {
+		case networkingStatusReady, networkingStatusPendingAccept:
+			return transitGatewayAttachment, stateDone, nil
+		case stateFailed:
+			return nil, stateFailed, fmt.Errorf("[ERROR] Transit Gateway Attachment provisioning has failed: %s", transitGatewayAttachment.Status.failureMessage())
+		}
+		return transitGatewayAttachment, stateInProgress, nil
+	}
+}
+
+func transitGatewayAttachmentDeleteStatus(ctx context.Context, c *Client, environmentId string, transitGatewayAttachmentId string) resource.StateRefreshFunc {
+	return func() (result interface{}, s string, err error) {
+		transitGatewayAttachment, resp, err := executeTransitGatewayAttachmentRead(ctx, c, environmentId, transitGatewayAttachmentId)
+		if err != nil {
+			// 404 means that the Transit Gateway Attachment has been deleted
+			if HasStatusNotFound(resp) {
+				// Result (the 1st argument) can't be nil
+				return 0, stateDone, nil
+			}
+			log.Printf("[ERROR] Transit Gateway Attachment get failed for id %s, %+v, %s", transitGatewayAttachmentId, resp, err)
+			return nil, stateUnknown, err
+		}
+		log.Printf("[DEBUG] Waiting for Transit Gateway Attachment to be deleted: current status %s", transitGatewayAttachment.Status.Phase)
+		return transitGatewayAttachment, stateInProgress, nil
+	}
+}